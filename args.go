@@ -0,0 +1,218 @@
+package flags
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	argNameValidationPattern = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$")
+)
+
+//Arg adds a required positional argument to the set
+//The type of init (bool, int or string) determines the type of the argument
+func (set *Set) Arg(name string, init interface{}, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.Arg() called on set==nil")
+	}
+	newArgPtr, err := set.addArg(name, 1, 1, init, nil, doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Arg() cannot add <%s>: %w", name, err)
+	}
+	return newArgPtr, nil
+} //Set.Arg()
+
+//OptionalArg adds a positional argument that keeps the init value when not specified
+//The type of init (bool, int or string) determines the type of the argument
+func (set *Set) OptionalArg(name string, init interface{}, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.OptionalArg() called on set==nil")
+	}
+	newArgPtr, err := set.addArg(name, 0, 1, init, nil, doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.OptionalArg() cannot add <%s>: %w", name, err)
+	}
+	return newArgPtr, nil
+} //Set.OptionalArg()
+
+//SelectArg adds a required positional argument allowing one of the specified values
+func (set *Set) SelectArg(name string, allow []string, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.SelectArg() called on set==nil")
+	}
+	newArgPtr, err := set.addArg(
+		name,
+		1,
+		1,
+		"",
+		func(value interface{}) error {
+			for _, s := range allow {
+				if s == value.(string) {
+					return nil
+				}
+			}
+			return fmt.Errorf("<%s> value \"%s\" must be one of %v", name, value.(string), allow)
+		},
+		doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.SelectArg() cannot add <%s>: %w", name, err)
+	}
+	newArgPtr.allow = append([]string{}, allow...)
+	return newArgPtr, nil
+} //Set.SelectArg()
+
+//Args adds a variadic positional argument taking min..max values, use max<0 for no limit
+//The type of init ([]bool, []int or []string) determines the type of the values
+//e.g. for "cp SRC... DST": set.Args("src", 1, -1, []string{}, ...) then set.Arg("dst", "", ...)
+func (set *Set) Args(name string, min, max int, init interface{}, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.Args() called on set==nil")
+	}
+	switch init.(type) {
+	case []bool, []int, []string:
+	default:
+		return nil, fmt.Errorf("Set.Args() cannot add <%s>: init must be []bool, []int or []string, not %T", name, init)
+	}
+	if min < 0 || (max >= 0 && max < min) || max == 0 {
+		return nil, fmt.Errorf("Set.Args() cannot add <%s>: invalid min=%d..max=%d", name, min, max)
+	}
+	newArgPtr, err := set.addArg(name, min, max, init, nil, doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Args() cannot add <%s>: %w", name, err)
+	}
+	newArgPtr.variadic = true
+	return newArgPtr, nil
+} //Set.Args()
+
+//addArg validates and adds a positional argument to the set
+func (set *Set) addArg(name string, min, max int, init interface{}, validateFunc FlagValueValidationFunc, doc string) (*FlagDescription, error) {
+	if !argNameValidationPattern.MatchString(name) {
+		return nil, fmt.Errorf("Argument name \"%s\" must be a word starting with a letter or digit", name)
+	}
+	value, err := newValue(init)
+	if err != nil {
+		return nil, err
+	}
+	if doc == "" {
+		return nil, fmt.Errorf("Argument without documentation")
+	}
+	for _, arg := range set.args {
+		if arg.name == name {
+			return nil, fmt.Errorf("Duplicate argument <%s>", name)
+		}
+	}
+	newArgPtr := &FlagDescription{
+		index:    len(set.args),
+		name:     name,
+		min:      min,
+		max:      max,
+		value:    value,
+		validate: validateFunc,
+		doc:      doc,
+	}
+	newArgPtr.defaultValue = value.String()
+	set.args = append(set.args, newArgPtr)
+	return newArgPtr, nil
+} //Set.addArg()
+
+//isOption is true when the argument looks like an option rather than a value
+//Negative numbers like "-5" that are not defined as options are values.
+func isOption(opt string) bool {
+	if len(opt) < 2 || !strings.HasPrefix(opt, "-") {
+		return false
+	}
+	_, err := strconv.ParseFloat(opt, 64)
+	return err != nil
+} //isOption()
+
+//assignArgs distributes the positional values over the defined arguments,
+//giving each its minimum and the variadic ones as many extra as possible,
+//and returns the values that could not be assigned
+func (set *Set) assignArgs(values []cmdArg) ([]cmdArg, error) {
+	needed := 0
+	for _, arg := range set.args {
+		needed += arg.min
+	}
+	if len(values) < needed {
+		//report all the missing arguments, taking the same distribution
+		missing := make([]string, 0)
+		n := len(values)
+		for _, arg := range set.args {
+			if n >= arg.min {
+				n -= arg.min
+				continue
+			}
+			missing = append(missing, fmt.Sprintf("%n", arg))
+			n = 0
+		}
+		return values, fmt.Errorf("Missing arguments: %s", strings.Join(missing, " "))
+	}
+
+	extra := len(values) - needed
+	next := 0
+	for _, arg := range set.args {
+		count := arg.min
+		if arg.max < 0 || arg.max-arg.min >= extra {
+			count += extra
+			extra = 0
+		} else {
+			count += arg.max - arg.min
+			extra -= arg.max - arg.min
+		}
+		if err := arg.setArgValues(values[next : next+count]); err != nil {
+			return values[next:], err
+		}
+		next += count
+	}
+	return values[next:], nil
+} //Set.assignArgs()
+
+//setArgValues stores the values specified for a positional argument
+func (f *FlagDescription) setArgValues(values []cmdArg) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if l, ok := f.value.(interface{ reset() }); ok {
+		l.reset()
+		for _, a := range values {
+			if err := f.set(a.s); err != nil {
+				return fromArg(err, a)
+			}
+		}
+	} else {
+		if err := f.set(values[0].s); err != nil {
+			return fromArg(err, values[0])
+		}
+	}
+	f.source = sourceArgs
+	return nil
+} //FlagDescription.setArgValues()
+
+//Synopsis describes the command line of the set, e.g. "[options] <src>... <dst>"
+func (set Set) Synopsis() string {
+	s := ""
+	if len(set.flags) > 0 {
+		s = "[options]"
+	}
+	for _, arg := range set.args {
+		a := arg.argUsage()
+		if arg.min == 0 {
+			a = "[" + a + "]"
+		}
+		if s != "" {
+			s += " "
+		}
+		s += a
+	}
+	return s
+} //Set.Synopsis()
+
+//argUsage is the name of the argument as shown in usage, e.g. "<src>..."
+func (f FlagDescription) argUsage() string {
+	if f.variadic {
+		return fmt.Sprintf("%n...", f)
+	}
+	return fmt.Sprintf("%n", f)
+} //FlagDescription.argUsage()
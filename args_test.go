@@ -0,0 +1,162 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+//newCopySet makes a set like "cp [-r] <src>... <dst>"
+func newCopySet(t *testing.T) *Set {
+	t.Helper()
+	set := NewSet("cp", "Copy files")
+	if _, err := set.Bool("-r", "--recursive", false, "Recursive"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := set.Args("src", 1, -1, []string{}, "Source"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := set.Arg("dst", "", "Destination"); err != nil {
+		t.Fatal(err)
+	}
+	return set
+} //newCopySet()
+
+func TestPositionalArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		optional  bool
+		maxSrc    int
+		args      []string
+		remaining []string
+		values    map[string]string
+		err       string
+	}{
+		{
+			name:      "minimum",
+			args:      []string{"a", "b"},
+			remaining: []string{},
+			values:    map[string]string{"src": "[a]", "dst": "b"},
+		},
+		{
+			name:      "variadic takes the extra values",
+			args:      []string{"a", "-r", "b", "c"},
+			remaining: []string{},
+			values:    map[string]string{"src": "[a b]", "dst": "c", "-r": "true"},
+		},
+		{
+			name:      "values after end of options",
+			args:      []string{"a", "--", "-b", "c"},
+			remaining: []string{},
+			values:    map[string]string{"src": "[a -b]", "dst": "c"},
+		},
+		{
+			name:      "optional argument after the variadic gets nothing",
+			optional:  true,
+			args:      []string{"a", "b", "c"},
+			remaining: []string{},
+			values:    map[string]string{"src": "[a b]", "dst": "c", "mode": "copy"},
+		},
+		{
+			name:      "limited variadic leaves values for the optional argument",
+			optional:  true,
+			maxSrc:    1,
+			args:      []string{"a", "b", "c"},
+			remaining: []string{},
+			values:    map[string]string{"src": "[a]", "dst": "b", "mode": "c"},
+		},
+		{
+			name:      "extra values remain",
+			maxSrc:    1,
+			args:      []string{"a", "b", "c", "--", "d"},
+			remaining: []string{"c", "d"},
+			values:    map[string]string{"src": "[a]", "dst": "b"},
+		},
+		{
+			name:      "extra values after end of options remain",
+			maxSrc:    1,
+			args:      []string{"a", "--", "b", "c"},
+			remaining: []string{"c"},
+			values:    map[string]string{"src": "[a]", "dst": "b"},
+		},
+		{
+			name:      "negative numbers are values",
+			args:      []string{"-5", "-r", "-1.5"},
+			remaining: []string{},
+			values:    map[string]string{"src": "[-5]", "dst": "-1.5", "-r": "true"},
+		},
+		{
+			name: "missing",
+			args: []string{"a"},
+			err:  "Missing arguments: <dst>",
+		},
+		{
+			name: "all missing",
+			args: []string{"-r"},
+			err:  "Missing arguments: <src> <dst>",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := NewSet("cp", "Copy files")
+			if _, err := set.Bool("-r", "--recursive", false, "Recursive"); err != nil {
+				t.Fatal(err)
+			}
+			max := -1
+			if test.maxSrc > 0 {
+				max = test.maxSrc
+			}
+			if _, err := set.Args("src", 1, max, []string{}, "Source"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := set.Arg("dst", "", "Destination"); err != nil {
+				t.Fatal(err)
+			}
+			if test.optional {
+				if _, err := set.OptionalArg("mode", "copy", "Mode"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			remaining, err := set.ParseKnown(test.args)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("ParseKnown(%q) error %v, expected %q", test.args, err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseKnown(%q) failed: %v", test.args, err)
+			}
+			if !reflect.DeepEqual(remaining, test.remaining) {
+				t.Errorf("ParseKnown(%q) remaining %q, expected %q", test.args, remaining, test.remaining)
+			}
+			for name, expected := range test.values {
+				if got := fmt.Sprintf("%v", set.Flag(name).Value()); got != expected {
+					t.Errorf("ParseKnown(%q) %s=%s, expected %s", test.args, name, got, expected)
+				}
+			}
+		})
+	}
+} //TestPositionalArgs()
+
+func TestNegativeNumberArg(t *testing.T) {
+	set := NewSet("test", "Test set")
+	n, err := set.Arg("n", 0, "Number")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := set.Int("-l", "--limit", 2, "Limit"); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"-l", "-3", "-5"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if n.Value() != -5 || set.Flag("-l").Value() != -3 {
+		t.Errorf("got <n>=%v -l=%v, expected -5 and -3", n.Value(), set.Flag("-l").Value())
+	}
+	var unknown *UnknownFlagError
+	if err := set.Parse([]string{"-5x"}); !errors.As(err, &unknown) {
+		t.Errorf("Parse(-5x) expected *UnknownFlagError, got %v", err)
+	}
+} //TestNegativeNumberArg()
@@ -0,0 +1,290 @@
+package flags
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//BindStruct adds a flag for each tagged field of the struct that ptr points to,
+//and parsing writes the values into the fields, e.g.
+//
+//	type config struct {
+//		Limit int    `flag:"-l,--limit" doc:"Limit nr of records" default:"2"`
+//		Input string `flag:"--input" doc:"Input filename"`
+//		DB    struct {
+//			Host string `flag:"--host" doc:"Database host"`
+//		} `flag:"--db"`
+//	}
+//
+//Fields tagged with a long option that are structs prefix the long options
+//of their fields, so DB.Host above is --db-host, and untagged struct fields
+//add their fields without a prefix. Use flag:"-" to skip a field.
+//
+//Fields tagged with group:"-o,--oper" must be structs that become a Group flag:
+//each struct field in it is an option with a set of its own flags, named by
+//its name:"..." tag (or the lower case field name) and documented by its doc
+//tag, and a string field tagged selected:"" receives the selected name.
+//
+//Without a default tag, the current value of the field is the default,
+//and fields tagged with required:"" must be specified.
+//Fields of type []string and []int are list flags, split on a sep:"," tag if present,
+//and map[string]string fields are map flags, with the key/value separator in a sep tag.
+//Fields of type time.Duration take values like "1m30s", and fields of types
+//implementing Value or encoding.TextUnmarshaler are parsed like with Set.Var()
+//and Set.Text(), also when they are structs.
+//The fields are shared by copies of the flags, so parsing a set that the flags
+//were copied to with Set.AddSet() also writes into the fields.
+func (set *Set) BindStruct(ptr interface{}) error {
+	if set == nil {
+		return fmt.Errorf("Set.BindStruct() called on set==nil")
+	}
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Set.BindStruct() needs a pointer to a struct, not %T", ptr)
+	}
+	if err := set.bindStruct(v.Elem(), ""); err != nil {
+		return fmt.Errorf("Set.BindStruct(%T) failed: %w", ptr, err)
+	}
+	return nil
+} //Set.BindStruct()
+
+//bindStruct adds flags for the fields of struct v, with prefix added to long options
+func (set *Set) bindStruct(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		flagTag, hasFlag := field.Tag.Lookup("flag")
+		groupTag, hasGroup := field.Tag.Lookup("group")
+		if flagTag == "-" {
+			continue
+		}
+		if !field.IsExported() {
+			if hasFlag || hasGroup {
+				return fmt.Errorf("Field %s is not exported", field.Name)
+			}
+			continue
+		}
+		if !hasFlag && !hasGroup {
+			if field.Type.Kind() == reflect.Struct {
+				if err := set.bindStruct(v.Field(i), prefix); err != nil {
+					return fmt.Errorf("%s.%w", field.Name, err)
+				}
+			}
+			continue
+		}
+
+		tag := flagTag
+		if hasGroup {
+			tag = groupTag
+		}
+		short, long, err := splitFlagTag(tag)
+		if err != nil {
+			return fmt.Errorf("Field %s: %w", field.Name, err)
+		}
+		if long != "" && prefix != "" {
+			long = "--" + prefix + long[2:]
+		}
+
+		if hasGroup {
+			if err := set.bindGroup(v.Field(i), short, long, field.Tag.Get("doc")); err != nil {
+				return fmt.Errorf("Field %s: %w", field.Name, err)
+			}
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct && !isValueField(v.Field(i)) {
+			if short != "" || long == "" {
+				return fmt.Errorf("Field %s: struct must be tagged with only a long option", field.Name)
+			}
+			if err := set.bindStruct(v.Field(i), long[2:]+"-"); err != nil {
+				return fmt.Errorf("%s.%w", field.Name, err)
+			}
+			continue
+		}
+
+		value, err := newFieldValue(v.Field(i))
+		if err != nil {
+			return fmt.Errorf("Field %s: %w", field.Name, err)
+		}
+		newFlag, err := newFlag(short, long, value, nil, field.Tag.Get("doc"))
+		if err != nil {
+			return fmt.Errorf("Field %s: %w", field.Name, err)
+		}
+		newFlag.bound = true
+		if sep, ok := field.Tag.Lookup("sep"); ok {
+			newFlag.SetSeparator(sep)
+		}
+		if _, ok := field.Tag.Lookup("required"); ok {
+			newFlag.Required()
+		}
+		if def, ok := field.Tag.Lookup("default"); ok {
+			if l, ok := value.(interface{ reset() }); ok {
+				l.reset()
+			}
+			if err := value.Set(def); err != nil {
+				return fmt.Errorf("Field %s default \"%s\" is not valid: %w", field.Name, def, err)
+			}
+		}
+		if _, err := set.Add(newFlag); err != nil {
+			return fmt.Errorf("Field %s: %w", field.Name, err)
+		}
+	} //for each field
+	return nil
+} //Set.bindStruct()
+
+//bindGroup adds a Group flag for struct v, with a set for each struct field in it
+func (set *Set) bindGroup(v reflect.Value, short, long, doc string) error {
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("group must be a struct, not %v", v.Type())
+	}
+	groupFlag, err := set.Group(short, long, doc)
+	if err != nil {
+		return err
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if _, ok := field.Tag.Lookup("selected"); ok {
+			p, ok := v.Field(i).Addr().Interface().(*string)
+			if !ok {
+				return fmt.Errorf("selected field %s must be a string", field.Name)
+			}
+			*p = groupFlag.value.String()
+			groupFlag.value = newScalar(p)
+			groupFlag.bound = true
+			continue
+		}
+		if field.Type.Kind() != reflect.Struct {
+			continue
+		}
+		name := field.Tag.Get("name")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		optionSet := NewSet(name, field.Tag.Get("doc"))
+		if err := optionSet.bindStruct(v.Field(i), ""); err != nil {
+			return fmt.Errorf("%s.%w", field.Name, err)
+		}
+		if err := groupFlag.Add(optionSet); err != nil {
+			return err
+		}
+	} //for each option
+	return nil
+} //Set.bindGroup()
+
+//splitFlagTag splits a tag like "-l,--limit" into short and long options
+func splitFlagTag(tag string) (short, long string, err error) {
+	for _, opt := range strings.Split(tag, ",") {
+		opt = strings.TrimSpace(opt)
+		switch {
+		case opt == "":
+		case strings.HasPrefix(opt, "--") && long == "":
+			long = opt
+		case !strings.HasPrefix(opt, "--") && strings.HasPrefix(opt, "-") && short == "":
+			short = opt
+		default:
+			return "", "", fmt.Errorf("Invalid flag tag \"%s\", expecting \"-s,--long\"", tag)
+		}
+	}
+	return short, long, nil
+} //splitFlagTag()
+
+//isValueField is true for fields that hold a single value, even when they are structs
+func isValueField(field reflect.Value) bool {
+	switch field.Addr().Interface().(type) {
+	case Value, encoding.TextUnmarshaler:
+		return true
+	}
+	return false
+} //isValueField()
+
+//newFieldValue makes a value that stores into the struct field
+func newFieldValue(field reflect.Value) (Value, error) {
+	switch p := field.Addr().Interface().(type) {
+	case Value:
+		return p, nil
+	case encoding.TextUnmarshaler:
+		return &textValue{p: p}, nil
+	case *time.Duration:
+		return &durationValue{p: p}, nil
+	case *bool:
+		return newScalar(p), nil
+	case *int:
+		return newScalar(p), nil
+	case *string:
+		return newScalar(p), nil
+	case *int8:
+		return newScalar(p), nil
+	case *int16:
+		return newScalar(p), nil
+	case *int32:
+		return newScalar(p), nil
+	case *int64:
+		return newScalar(p), nil
+	case *uint:
+		return newScalar(p), nil
+	case *uint8:
+		return newScalar(p), nil
+	case *uint16:
+		return newScalar(p), nil
+	case *uint32:
+		return newScalar(p), nil
+	case *uint64:
+		return newScalar(p), nil
+	case *float32:
+		return newScalar(p), nil
+	case *float64:
+		return newScalar(p), nil
+	case *[]string:
+		return newList(p), nil
+	case *[]int:
+		return newList(p), nil
+	case *map[string]string:
+		if *p == nil {
+			*p = make(map[string]string)
+		}
+		return newMapValue(p), nil
+	}
+	return nil, fmt.Errorf("Fields of type %v is not supported", field.Type())
+} //newFieldValue()
+
+//durationValue holds a time.Duration, parsed with time.ParseDuration(), e.g. "1m30s"
+type durationValue struct {
+	p *time.Duration
+}
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*v.p = d
+	return nil
+}
+
+func (v *durationValue) String() string {
+	return v.p.String()
+}
+
+func (v *durationValue) Get() interface{} {
+	return *v.p
+}
+
+//TypeName describes the type in usage
+func (v *durationValue) TypeName() string {
+	return "duration"
+}
+
+func (v *durationValue) save() interface{} {
+	return *v.p
+}
+
+func (v *durationValue) restore(saved interface{}) {
+	*v.p = saved.(time.Duration)
+}
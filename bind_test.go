@@ -0,0 +1,184 @@
+package flags
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+//level is a user-defined Value
+type level int
+
+func (l *level) Set(s string) error {
+	for i, name := range []string{"low", "high"} {
+		if s == name {
+			*l = level(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown level %s", s)
+}
+
+func (l *level) String() string {
+	return []string{"low", "high"}[*l]
+}
+
+//point is a struct parsed with UnmarshalText()
+type point struct {
+	X, Y int
+}
+
+func (p *point) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%d,%d", &p.X, &p.Y)
+	return err
+}
+
+type bindConfig struct {
+	Limit   int               `flag:"-l,--limit" doc:"Limit" default:"2"`
+	Input   string            `flag:"--input" doc:"Input" required:""`
+	Tags    []string          `flag:"-t,--tag" doc:"Tags" sep:","`
+	Labels  map[string]string `flag:"--label" doc:"Labels"`
+	Timeout time.Duration     `flag:"--timeout" doc:"Timeout" default:"5s"`
+	IP      net.IP            `flag:"--ip" doc:"Address"`
+	Level   level             `flag:"--level" doc:"Level"`
+	Origin  point             `flag:"--origin" doc:"Origin"`
+	Skipped string            `flag:"-"`
+	DB      struct {
+		Host string `flag:"--host" doc:"Database host"`
+	} `flag:"--db"`
+	Oper struct {
+		Selected string `selected:""`
+		Add      struct {
+			User string `flag:"-u,--user" doc:"User to add"`
+		} `doc:"Add a user"`
+		Del struct {
+			Force bool `flag:"-f,--force" doc:"Force"`
+		} `name:"delete" doc:"Delete a user"`
+	} `group:"-o,--oper" doc:"Operation"`
+}
+
+func TestBindStruct(t *testing.T) {
+	var c bindConfig
+	set := NewSet("test", "Test set")
+	if err := set.BindStruct(&c); err != nil {
+		t.Fatalf("BindStruct() failed: %v", err)
+	}
+	if c.Limit != 2 || c.Timeout != 5*time.Second {
+		t.Errorf("defaults not set: %+v", c)
+	}
+	err := set.Parse([]string{
+		"--input=in.txt", "-t", "a,b", "--label=k=v", "--timeout=1m30s", "--ip=10.0.0.1",
+		"--level=high", "--origin=3,4", "--db-host=db", "-o", "delete", "-f",
+	})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	expected := bindConfig{
+		Limit:   2,
+		Input:   "in.txt",
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"k": "v"},
+		Timeout: 90 * time.Second,
+		IP:      net.ParseIP("10.0.0.1"),
+		Level:   1,
+		Origin:  point{3, 4},
+	}
+	expected.DB.Host = "db"
+	expected.Oper.Selected = "delete"
+	expected.Oper.Del.Force = true
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("got %+v, expected %+v", c, expected)
+	}
+
+	set = NewSet("test", "Test set")
+	if err := set.BindStruct(&bindConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"-l", "1"}); err == nil || !strings.Contains(err.Error(), "--input") {
+		t.Errorf("expected required --input, got %v", err)
+	}
+	if err := set.Parse([]string{"--input=x", "--timeout=soon"}); err == nil {
+		t.Errorf("expected invalid duration to fail")
+	}
+} //TestBindStruct()
+
+func TestBindStructAddSet(t *testing.T) {
+	var c bindConfig
+	bound := NewSet("bound", "Bound set")
+	if err := bound.BindStruct(&c); err != nil {
+		t.Fatal(err)
+	}
+	set := NewSet("test", "Test set")
+	if err := set.AddSet(*bound); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"--input=x", "-l", "7", "-t", "a", "--timeout=1s", "--ip=::1", "--level=high", "-o", "add", "-u", "joe"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if c.Input != "x" || c.Limit != 7 || !reflect.DeepEqual(c.Tags, []string{"a"}) || c.Timeout != time.Second ||
+		!c.IP.Equal(net.ParseIP("::1")) || c.Level != 1 || c.Oper.Selected != "add" || c.Oper.Add.User != "joe" {
+		t.Errorf("parsing the copied flags did not write the fields: %+v", c)
+	}
+} //TestBindStructAddSet()
+
+func TestBindStructErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		ptr  interface{}
+		err  string
+	}{
+		{
+			name: "not a pointer",
+			ptr:  bindConfig{},
+			err:  "needs a pointer to a struct",
+		},
+		{
+			name: "unexported field",
+			ptr: &struct {
+				limit int `flag:"-l" doc:"Limit"`
+			}{},
+			err: "Field limit is not exported",
+		},
+		{
+			name: "unsupported type",
+			ptr: &struct {
+				C chan int `flag:"-c" doc:"Channel"`
+			}{},
+			err: "Field C: Fields of type chan int is not supported",
+		},
+		{
+			name: "invalid default",
+			ptr: &struct {
+				Timeout time.Duration `flag:"--timeout" doc:"Timeout" default:"soon"`
+			}{},
+			err: `Field Timeout default "soon" is not valid`,
+		},
+		{
+			name: "invalid tag",
+			ptr: &struct {
+				Limit int `flag:"limit" doc:"Limit"`
+			}{},
+			err: "Invalid flag tag",
+		},
+		{
+			name: "struct with short option",
+			ptr: &struct {
+				DB struct {
+					Host string `flag:"--host" doc:"Host"`
+				} `flag:"-d"`
+			}{},
+			err: "struct must be tagged with only a long option",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := NewSet("test", "Test set").BindStruct(test.ptr)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("BindStruct() error %v, expected %q", err, test.err)
+			}
+		})
+	}
+} //TestBindStructErrors()
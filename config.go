@@ -0,0 +1,249 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+)
+
+//Config adds a string flag naming a JSON file to load flag values from,
+//e.g. set.Config("", "--config", "Load options from JSON file")
+//The file is loaded after parsing, so values on the command line and from
+//the environment take precedence over values in the file.
+//When copied with Set.AddSet(), the flag loads the file into the set it was copied to.
+func (set *Set) Config(short, long string, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.Config() called on set==nil")
+	}
+	newFlagPtr, err := set.String(short, long, "", doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Config() cannot add %s %s: %w", short, long, err)
+	}
+	set.configFlag = newFlagPtr
+	return newFlagPtr, nil
+} //Set.Config()
+
+//LoadJSON sets flag values from a JSON object keyed by long option names
+//without the dashes, e.g. {"limit": 5, "output": "/tmp/x"}
+//Values must be of the same kind as the flag and are validated like
+//command line values, and values of user-defined types are strings.
+//Group flags take either the name of the selected option, or a nested
+//object with the values for each option's set,
+//e.g. {"oper": {"add": {"name": "Joe"}}}
+//Only the object of the selected option is loaded, where a single option
+//in the object is selected when nothing was selected before.
+//Values already specified on the command line or in the environment are kept.
+func (set *Set) LoadJSON(r io.Reader) error {
+	if set == nil {
+		return fmt.Errorf("(nil).LoadJSON")
+	}
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	values := make(map[string]interface{})
+	if err := decoder.Decode(&values); err != nil {
+		return fmt.Errorf("Cannot decode JSON config: %w", err)
+	}
+	return set.loadValues(values)
+} //Set.LoadJSON()
+
+//loadValues sets flags from decoded JSON values
+func (set *Set) loadValues(values map[string]interface{}) error {
+	names := make([]string, 0, len(values))
+	unknown := make([]string, 0)
+	for name := range values {
+		if _, ok := set.longFlag("--" + name); !ok {
+			unknown = append(unknown, name)
+		}
+		names = append(names, name)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("Unknown config options: %v", unknown)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		flag, _ := set.longFlag("--" + name)
+		if err := flag.loadValue(values[name]); err != nil {
+			return fmt.Errorf("config \"%s\": %w", name, err)
+		}
+	}
+	return nil
+} //Set.loadValues()
+
+//loadValue sets the flag from a decoded JSON value
+func (f *FlagDescription) loadValue(value interface{}) error {
+	if f.group != nil {
+		if options, ok := value.(map[string]interface{}); ok {
+			return f.loadGroupValues(options)
+		}
+	}
+
+	//keep values from the command line and environment
+	if f.source > sourceConfig {
+		return nil
+	}
+
+	//maps take an object with string values
+	if m, ok := f.value.(*mapValue); ok {
+		pairs, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("Expecting %n to be an object, not %v", f, value)
+		}
+		keys := make([]string, 0, len(pairs))
+		for k := range pairs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		m.reset()
+		for _, k := range keys {
+			s, ok := pairs[k].(string)
+			if !ok {
+				return fmt.Errorf("Expecting %n value of \"%s\" to be a <string>, not %v", f, k, pairs[k])
+			}
+			if err := f.set(k + m.sep + s); err != nil {
+				return err
+			}
+		}
+		f.source = sourceConfig
+		return nil
+	}
+
+	//lists take an array of values
+	if l, ok := f.value.(interface {
+		reset()
+		quote(string) string
+	}); ok {
+		elements, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("Expecting %n to be an array, not %v", f, value)
+		}
+		sample := reflect.Zero(reflect.TypeOf(f.get()).Elem()).Interface()
+		l.reset()
+		for _, element := range elements {
+			valueString, err := f.jsonText(sample, element)
+			if err != nil {
+				return err
+			}
+			if err := f.set(l.quote(valueString)); err != nil {
+				return err
+			}
+		}
+		f.source = sourceConfig
+		return nil
+	}
+
+	valueString, err := f.jsonText(f.get(), value)
+	if err != nil {
+		return err
+	}
+	return f.setFrom(sourceConfig, valueString)
+} //FlagDescription.loadValue()
+
+//jsonText checks that a decoded JSON value is of the same kind as the sample value
+//and returns it as text, to parse and validate it like a command line value
+func (f *FlagDescription) jsonText(sample interface{}, value interface{}) (string, error) {
+	switch sample.(type) {
+	case bool:
+		b, ok := value.(bool)
+		if !ok {
+			return "", fmt.Errorf("Expecting %n to be true or false, not %v", f, value)
+		}
+		return fmt.Sprintf("%v", b), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return "", fmt.Errorf("Expecting %n to be <%s>, not %v", f, f.typeName(), value)
+		}
+		return n.String(), nil
+	case string:
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("Expecting %n to be a <string>, not %v", f, value)
+		}
+		return s, nil
+	}
+	//values of other types are parsed from text
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("Expecting %n to be a <%s> string, not %v", f, f.typeName(), value)
+	}
+	return s, nil
+} //FlagDescription.jsonText()
+
+//loadGroupValues loads the nested object of the selected group option into its set,
+//after selecting the option when it is the only one and nothing was selected before
+//Objects of the other options are ignored, so that their flags are not set or validated.
+func (f *FlagDescription) loadGroupValues(options map[string]interface{}) error {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := f.validateGroupSelect(name); err != nil {
+			return err
+		}
+		if _, ok := options[name].(map[string]interface{}); !ok {
+			return fmt.Errorf("Expecting %n %s to be an object, not %v", f, name, options[name])
+		}
+	}
+	if len(names) == 1 && f.Selected() == nil {
+		if err := f.setFrom(sourceConfig, names[0]); err != nil {
+			return err
+		}
+	}
+	selected := f.Selected()
+	if selected == nil {
+		return nil
+	}
+	name := f.value.String()
+	values, ok := options[name].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if err := selected.loadValues(values); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+} //FlagDescription.loadGroupValues()
+
+//applyConfig loads the files named by config flags in the set and selected groups
+func (set *Set) applyConfig() error {
+	if set.configFlag != nil {
+		if filename := set.configFlag.value.String(); filename != "" {
+			file, err := os.Open(filename)
+			if err != nil {
+				return fmt.Errorf("Cannot open config %s: %w", filename, err)
+			}
+			defer file.Close()
+			if err := set.LoadJSON(file); err != nil {
+				return fmt.Errorf("Config %s: %w", filename, err)
+			}
+		}
+	}
+	for _, flag := range set.flags {
+		if selected := flag.Selected(); selected != nil {
+			if err := selected.applyConfig(); err != nil {
+				return fmt.Errorf("%n %s: %w", flag, flag.value, err)
+			}
+		}
+	}
+	return nil
+} //Set.applyConfig()
+
+//applySources completes parsing with values from the environment and config files,
+//applying the environment again after the config, so it takes precedence and
+//also applies to groups selected in the config
+func (set *Set) applySources() error {
+	if err := set.applyEnv(""); err != nil {
+		return err
+	}
+	if err := set.applyConfig(); err != nil {
+		return err
+	}
+	return set.applyEnv("")
+} //Set.applySources()
@@ -0,0 +1,160 @@
+package flags
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		json   string
+		values map[string]string
+		err    string
+	}{
+		{
+			name:   "values of each kind",
+			json:   `{"limit": 5, "debug": true, "tag": ["a", "b,c"], "colour": "green", "verbose": 2}`,
+			values: map[string]string{"-l": "5", "-d": "true", "-t": "[a b,c]", "-c": "green", "-v": "2"},
+		},
+		{
+			name:   "command line takes precedence",
+			args:   []string{"-l", "1", "-t", "x"},
+			json:   `{"limit": 5, "tag": ["a"], "error": true}`,
+			values: map[string]string{"-l": "1", "-t": "[x]", "-e": "true"},
+		},
+		{
+			name:   "group option selected by name",
+			json:   `{"oper": "del"}`,
+			values: map[string]string{"-o": "del", "-f": "false"},
+		},
+		{
+			name:   "single group option object selects it",
+			json:   `{"oper": {"del": {"user": "joe", "force": true}}}`,
+			values: map[string]string{"-o": "del", "-u": "joe", "-f": "true"},
+		},
+		{
+			name:   "only the selected group option object is loaded",
+			args:   []string{"-o", "add"},
+			json:   `{"oper": {"add": {"user": "joe"}, "del": {"force": "not a bool"}}}`,
+			values: map[string]string{"-o": "add", "-u": "joe"},
+		},
+		{
+			name:   "nothing selected from several group option objects",
+			json:   `{"oper": {"add": {"user": "joe"}, "del": {"user": "ann"}}}`,
+			values: map[string]string{"-o": ""},
+		},
+		{
+			name: "unknown options",
+			json: `{"limit": 1, "bogus": 1, "another": 2}`,
+			err:  "Unknown config options: [another bogus]",
+		},
+		{
+			name: "unknown group option",
+			json: `{"oper": {"mod": {}}}`,
+			err:  `config "oper"`,
+		},
+		{
+			name: "wrong kind of value",
+			json: `{"limit": "5"}`,
+			err:  `config "limit": Expecting -l to be <integer>, not 5`,
+		},
+		{
+			name: "list needs an array",
+			json: `{"tag": "a"}`,
+			err:  `config "tag": Expecting -t to be an array, not a`,
+		},
+		{
+			name: "invalid select value",
+			json: `{"colour": "blue"}`,
+			err:  `config "colour"`,
+		},
+		{
+			name: "not an object",
+			json: `[1]`,
+			err:  "Cannot decode JSON config",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := newTestSet(t)
+			if _, err := set.ParseKnown(test.args); err != nil {
+				t.Fatalf("ParseKnown(%q) failed: %v", test.args, err)
+			}
+			err := set.LoadJSON(strings.NewReader(test.json))
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("LoadJSON(%s) error %v, expected %q", test.json, err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadJSON(%s) failed: %v", test.json, err)
+			}
+			names := make([]string, 0, len(test.values))
+			for name := range test.values {
+				names = append(names, name)
+			}
+			if got := values(set, names); !reflect.DeepEqual(got, test.values) {
+				t.Errorf("LoadJSON(%s) values %v, expected %v", test.json, got, test.values)
+			}
+		})
+	}
+} //TestLoadJSON()
+
+func TestConfig(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(filename, []byte(`{"limit": 5, "error": true, "colour": "grey"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_COLOUR", "green")
+	set := newTestSet(t)
+	set.SetEnvPrefix("TEST")
+	if _, err := set.Config("", "--config", "Config file"); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"--config=" + filename, "-l", "1"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	expected := map[string]string{"-l": "1", "-e": "true", "-c": "green"}
+	if got := values(set, []string{"-l", "-e", "-c"}); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+
+	set = newTestSet(t)
+	if _, err := set.Config("", "--config", "Config file"); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	if err := set.Parse([]string{"--config=" + missing}); err == nil || !strings.HasPrefix(err.Error(), "Cannot open config") {
+		t.Errorf("expected missing config to fail, got %v", err)
+	}
+} //TestConfig()
+
+func TestConfigCopy(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(filename, []byte(`{"limit": 5}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	set := NewSet("copy", "Copied set")
+	if err := set.AddSet(*newTestSet(t)); err != nil {
+		t.Fatal(err)
+	}
+	other := NewSet("other", "Other set")
+	if _, err := other.Config("", "--config", "Config file"); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.AddSet(*other); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"--config=" + filename}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if got := set.Flag("-l").Value(); got != 5 {
+		t.Errorf("-l=%v, expected 5 from the config file of the copied config flag", got)
+	}
+} //TestConfigCopy()
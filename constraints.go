@@ -0,0 +1,192 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+)
+
+//constraintKind is the rule that a constraint applies to its flags
+type constraintKind int
+
+const (
+	//exclusive flags may not be specified together
+	exclusive constraintKind = iota
+	//requires the other flags when the first flag is specified
+	requires
+	//atLeastOne of the flags must be specified
+	atLeastOne
+)
+
+//constraint between flags of a set, checked after parsing
+type constraint struct {
+	kind  constraintKind
+	flags []*FlagDescription
+}
+
+//MutuallyExclusive fails parsing when more than one of the named flags is specified,
+//e.g. set.MutuallyExclusive("--input", "--stdin")
+//Only flags from the same source conflict: a flag on the command line overrules
+//the others from the environment or a config file, and the environment overrules
+//config, where the overruled flags are restored to their defaults, so that they
+//are no longer specified.
+func (set *Set) MutuallyExclusive(names ...string) error {
+	return set.addConstraint("MutuallyExclusive", exclusive, names)
+} //Set.MutuallyExclusive()
+
+//Requires fails parsing when the named flag is specified without all the required flags,
+//e.g. set.Requires("--tls-key", "--tls-cert")
+//Flags from the environment or a config file count as specified, both for the
+//named flag and for the required flags.
+func (set *Set) Requires(name string, required ...string) error {
+	return set.addConstraint("Requires", requires, append([]string{name}, required...))
+} //Set.Requires()
+
+//AtLeastOne fails parsing when none of the named flags is specified,
+//e.g. set.AtLeastOne("--id", "--name")
+//Flags from the environment or a config file count as specified.
+func (set *Set) AtLeastOne(names ...string) error {
+	return set.addConstraint("AtLeastOne", atLeastOne, names)
+} //Set.AtLeastOne()
+
+//addConstraint adds a constraint on the flags with the specified short/long options
+func (set *Set) addConstraint(method string, kind constraintKind, names []string) error {
+	if set == nil {
+		return fmt.Errorf("Set.%s() called on set==nil", method)
+	}
+	if len(names) < 2 {
+		return fmt.Errorf("Set.%s() needs at least 2 flags, not %v", method, names)
+	}
+	c := constraint{kind: kind, flags: make([]*FlagDescription, 0, len(names))}
+	for _, name := range names {
+		flag, ok := set.shortFlag(name)
+		if !ok {
+			flag, ok = set.longFlag(name)
+		}
+		if !ok {
+			return fmt.Errorf("Set.%s() unknown flag %s", method, name)
+		}
+		c.flags = append(c.flags, flag)
+	}
+	set.constraints = append(set.constraints, c)
+	return nil
+} //Set.addConstraint()
+
+//describe the constraint with the flags formatted by verb, e.g. "%N"
+func (c constraint) describe(verb string) string {
+	names := make([]string, 0, len(c.flags))
+	for _, flag := range c.flags {
+		names = append(names, fmt.Sprintf(verb, flag))
+	}
+	switch c.kind {
+	case exclusive:
+		return joinNames(names, "and") + " are mutually exclusive"
+	case requires:
+		return names[0] + " requires " + joinNames(names[1:], "and")
+	case atLeastOne:
+		return "One of " + joinNames(names, "or") + " is required"
+	}
+	return ""
+} //constraint.describe()
+
+//check fails when the constraint is broken by the specified flags
+func (c constraint) check() error {
+	specified := make([]*FlagDescription, 0)
+	for _, flag := range c.flags {
+		if flag.Specified() {
+			specified = append(specified, flag)
+		}
+	}
+	switch c.kind {
+	case exclusive:
+		if len(specified) > 1 {
+			return fmt.Errorf("%s", constraint{kind: exclusive, flags: specified}.describe("%N"))
+		}
+	case requires:
+		if !c.flags[0].Specified() {
+			return nil
+		}
+		missing := []*FlagDescription{c.flags[0]}
+		for _, flag := range c.flags[1:] {
+			if !flag.Specified() {
+				missing = append(missing, flag)
+			}
+		}
+		if len(missing) > 1 {
+			return fmt.Errorf("%s", constraint{kind: requires, flags: missing}.describe("%N"))
+		}
+	case atLeastOne:
+		if len(specified) == 0 {
+			return fmt.Errorf("%s", c.describe("%N"))
+		}
+	}
+	return nil
+} //constraint.check()
+
+//joinNames joins names with commas and the conjunction before the last,
+//e.g. "-a, -b and -c"
+func joinNames(names []string, conjunction string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " " + conjunction + " " + names[len(names)-1]
+} //joinNames()
+
+//check fails when required flags are missing or a constraint is broken
+//in the set or its selected groups, after discarding overruled exclusive flags
+func (set Set) check() error {
+	if err := set.discardOverruled(); err != nil {
+		return err
+	}
+	if err := set.checkRequired(); err != nil {
+		return err
+	}
+	return set.checkConstraints()
+} //Set.check()
+
+//discardOverruled restores the defaults of mutually exclusive flags from a source
+//of lower precedence than another of them, in the set and its selected groups
+func (set Set) discardOverruled() error {
+	for _, c := range set.constraints {
+		if c.kind != exclusive {
+			continue
+		}
+		highest := sourceDefault
+		for _, flag := range c.flags {
+			if flag.source > highest {
+				highest = flag.source
+			}
+		}
+		for _, flag := range c.flags {
+			if flag.Specified() && flag.source < highest {
+				if err := flag.resetDefault(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, flag := range set.flags {
+		if selected := flag.Selected(); selected != nil {
+			if err := selected.discardOverruled(); err != nil {
+				return fmt.Errorf("%n %s: %w", flag, flag.value, err)
+			}
+		}
+	}
+	return nil
+} //Set.discardOverruled()
+
+//checkConstraints fails on the first broken constraint in the set or its selected groups
+func (set Set) checkConstraints() error {
+	for _, c := range set.constraints {
+		if err := c.check(); err != nil {
+			return err
+		}
+	}
+	for _, flag := range set.flags {
+		if selected := flag.Selected(); selected != nil {
+			if err := selected.checkConstraints(); err != nil {
+				return fmt.Errorf("%n %s: %w", flag, flag.value, err)
+			}
+		}
+	}
+	return nil
+} //Set.checkConstraints()
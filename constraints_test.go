@@ -0,0 +1,281 @@
+package flags
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//constraintTest is a case for parsing a set with constraints, with values
+//from the environment, a config file and the command line
+type constraintTest struct {
+	name        string
+	copy        bool
+	env         map[string]string
+	config      string
+	args        []string
+	values      map[string]string
+	unspecified []string
+	err         string
+}
+
+//runConstraintTests parses a set with flags for each kind of constraint, after
+//adding constraints to it, or to another set that it copies the flags from
+func runConstraintTests(t *testing.T, constrain func(set *Set) error, tests []constraintTest) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			set := NewSet("test", "Test set")
+			must := func(_ *FlagDescription, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("cannot define flag: %v", err)
+				}
+			}
+			must(set.String("-i", "--input", "", "Input"))
+			must(set.Bool("", "--stdin", false, "Read stdin"))
+			must(set.String("", "--tls-key", "", "Key"))
+			must(set.String("", "--tls-cert", "", "Certificate"))
+			must(set.Int("", "--id", 0, "ID"))
+			must(set.String("", "--name", "", "Name"))
+			must(set.Config("", "--config", "Config file"))
+			if err := constrain(set); err != nil {
+				t.Fatal(err)
+			}
+			if test.copy {
+				copied := NewSet("copy", "Copied set")
+				if err := copied.AddSet(*set); err != nil {
+					t.Fatal(err)
+				}
+				set = copied
+			}
+			set.SetEnvPrefix("TEST")
+			args := test.args
+			if test.config != "" {
+				filename := filepath.Join(t.TempDir(), "config.json")
+				if err := os.WriteFile(filename, []byte(test.config), 0644); err != nil {
+					t.Fatal(err)
+				}
+				args = append([]string{"--config=" + filename}, args...)
+			}
+			err := set.Parse(args)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("Parse(%q) error %v, expected %q", args, err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", args, err)
+			}
+			for name, expected := range test.values {
+				if got := fmt.Sprintf("%v", set.Flag(name).Value()); got != expected {
+					t.Errorf("Parse(%q) %s=%s, expected %s", args, name, got, expected)
+				}
+			}
+			for _, name := range test.unspecified {
+				if set.Flag(name).Specified() {
+					t.Errorf("Parse(%q) %s is still specified", args, name)
+				}
+			}
+		})
+	}
+} //runConstraintTests()
+
+func TestMutuallyExclusive(t *testing.T) {
+	runConstraintTests(t,
+		func(set *Set) error { return set.MutuallyExclusive("--input", "--stdin") },
+		[]constraintTest{
+			{
+				name:   "one",
+				args:   []string{"-i", "x"},
+				values: map[string]string{"-i": "x", "--stdin": "false"},
+			},
+			{
+				name: "both on the command line",
+				args: []string{"-i", "x", "--stdin"},
+				err:  "-i (--input) and --stdin are mutually exclusive",
+			},
+			{
+				name:        "command line overrules environment",
+				env:         map[string]string{"TEST_STDIN": "true"},
+				args:        []string{"--input=x"},
+				values:      map[string]string{"-i": "x", "--stdin": "false"},
+				unspecified: []string{"--stdin"},
+			},
+			{
+				name:        "environment overrules config",
+				env:         map[string]string{"TEST_INPUT": "x"},
+				config:      `{"stdin": true}`,
+				values:      map[string]string{"-i": "x", "--stdin": "false"},
+				unspecified: []string{"--stdin"},
+			},
+			{
+				name:        "command line overrules config",
+				config:      `{"input": "y"}`,
+				args:        []string{"--stdin"},
+				values:      map[string]string{"-i": "", "--stdin": "true"},
+				unspecified: []string{"-i"},
+			},
+			{
+				name: "both in the environment",
+				env:  map[string]string{"TEST_INPUT": "x", "TEST_STDIN": "true"},
+				err:  "-i (--input) and --stdin are mutually exclusive",
+			},
+			{
+				name:   "both in config",
+				config: `{"input": "y", "stdin": true}`,
+				err:    "-i (--input) and --stdin are mutually exclusive",
+			},
+			{
+				name: "copied both on the command line",
+				copy: true,
+				args: []string{"-i", "x", "--stdin"},
+				err:  "-i (--input) and --stdin are mutually exclusive",
+			},
+			{
+				name:        "copied command line overrules environment",
+				copy:        true,
+				env:         map[string]string{"TEST_STDIN": "true"},
+				args:        []string{"--input=x"},
+				values:      map[string]string{"-i": "x", "--stdin": "false"},
+				unspecified: []string{"--stdin"},
+			},
+		})
+} //TestMutuallyExclusive()
+
+func TestRequires(t *testing.T) {
+	runConstraintTests(t,
+		func(set *Set) error { return set.Requires("--tls-key", "--tls-cert") },
+		[]constraintTest{
+			{
+				name: "neither",
+			},
+			{
+				name: "both on the command line",
+				args: []string{"--tls-key=k", "--tls-cert=c"},
+			},
+			{
+				name: "required flag missing",
+				args: []string{"--tls-key=k"},
+				err:  "--tls-key requires --tls-cert",
+			},
+			{
+				name: "required flag only",
+				args: []string{"--tls-cert=c"},
+			},
+			{
+				name: "required flag missing with flag in the environment",
+				env:  map[string]string{"TEST_TLS_KEY": "k"},
+				err:  "--tls-key requires --tls-cert",
+			},
+			{
+				name:   "required flag missing with flag in config",
+				config: `{"tls-key": "k"}`,
+				err:    "--tls-key requires --tls-cert",
+			},
+			{
+				name: "required flag in the environment",
+				env:  map[string]string{"TEST_TLS_CERT": "c"},
+				args: []string{"--tls-key=k"},
+			},
+			{
+				name:   "required flag in config",
+				config: `{"tls-cert": "c"}`,
+				env:    map[string]string{"TEST_TLS_KEY": "k"},
+			},
+			{
+				name: "copied required flag missing with flag in the environment",
+				copy: true,
+				env:  map[string]string{"TEST_TLS_KEY": "k"},
+				err:  "--tls-key requires --tls-cert",
+			},
+			{
+				name: "copied both on the command line",
+				copy: true,
+				args: []string{"--tls-key=k", "--tls-cert=c"},
+			},
+		})
+} //TestRequires()
+
+func TestAtLeastOne(t *testing.T) {
+	runConstraintTests(t,
+		func(set *Set) error { return set.AtLeastOne("--id", "--name") },
+		[]constraintTest{
+			{
+				name: "none",
+				err:  "One of --id or --name is required",
+			},
+			{
+				name: "on the command line",
+				args: []string{"--id=1"},
+			},
+			{
+				name: "in the environment",
+				env:  map[string]string{"TEST_NAME": "joe"},
+			},
+			{
+				name:   "in config",
+				config: `{"id": 1}`,
+			},
+			{
+				name: "copied none",
+				copy: true,
+				err:  "One of --id or --name is required",
+			},
+			{
+				name: "copied on the command line",
+				copy: true,
+				args: []string{"--name=joe"},
+			},
+		})
+} //TestAtLeastOne()
+
+func TestConstraintErrors(t *testing.T) {
+	set := NewSet("test", "Test set")
+	if _, err := set.String("-i", "--input", "", "Input"); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.MutuallyExclusive("--input"); err == nil {
+		t.Errorf("expected a constraint on one flag to fail")
+	}
+	if err := set.Requires("--input", "--bogus"); err == nil || err.Error() != "Set.Requires() unknown flag --bogus" {
+		t.Errorf("expected a constraint on an unknown flag to fail, got %v", err)
+	}
+} //TestConstraintErrors()
+
+func TestMutuallyExclusiveRestoresDefaults(t *testing.T) {
+	t.Setenv("TEST_TAG", "x")
+	t.Setenv("TEST_LABEL", "k=v")
+	t.Setenv("TEST_VERBOSE", "2")
+	t.Setenv("TEST_IP", "10.0.0.1")
+	ip := net.ParseIP("127.0.0.1")
+	set := NewSet("test", "Test set")
+	set.SetEnvPrefix("TEST")
+	must := func(_ *FlagDescription, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("cannot define flag: %v", err)
+		}
+	}
+	must(set.StringList("", "--tag", []string{"a", "b"}, "Tags"))
+	must(set.Map("", "--label", map[string]string{"env": "dev"}, "Labels"))
+	must(set.Counter("", "--verbose", 0, "Verbose"))
+	must(set.Text("", "--ip", &ip, "Address"))
+	must(set.Bool("", "--quiet", false, "Quiet"))
+	if err := set.MutuallyExclusive("--quiet", "--tag", "--label", "--verbose", "--ip"); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"--quiet"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	for name, expected := range map[string]string{"--tag": "[a b]", "--label": "map[env:dev]", "--verbose": "0", "--ip": "127.0.0.1"} {
+		if got := fmt.Sprintf("%v", set.Flag(name).Value()); got != expected || set.Flag(name).Specified() {
+			t.Errorf("%s=%s, expected default %s", name, got, expected)
+		}
+	}
+} //TestMutuallyExclusiveRestoresDefaults()
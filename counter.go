@@ -0,0 +1,137 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//Counter adds a counter flag to the set, which counts how many times the
+//option is specified, e.g. -v -v or -vv for verbosity level 2, where repeating
+//the option like -vv is accepted without the ShortClusters syntax
+//A count can also be specified explicitly with --verbose=3 and reset with --verbose=0
+//When max > 0, counting beyond max is an error.
+func (set *Set) Counter(short, long string, max int, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.Counter() called on set==nil")
+	}
+	//create the new flag
+	value := 0
+	newFlag, err := newFlag(short, long, &counter{p: &value, max: max}, nil, doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Counter() cannot add %s %s: %w", short, long, err)
+	}
+	//add
+	newFlagPtr, err := set.Add(newFlag)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Counter() cannot add %s %s: %w", short, long, err)
+	}
+	return newFlagPtr, nil
+} //Set.Counter()
+
+//counter holds the count of a counter flag
+type counter struct {
+	p   *int
+	max int
+}
+
+//Set parses an explicit count
+func (c *counter) Set(s string) error {
+	n, err := parseInt(s, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	if n < 0 {
+		return fmt.Errorf("count may not be negative")
+	}
+	if c.max > 0 && int(n) > c.max {
+		return fmt.Errorf("count may not be more than %d", c.max)
+	}
+	*c.p = int(n)
+	return nil
+} //counter.Set()
+
+func (c *counter) String() string {
+	return fmt.Sprintf("%d", *c.p)
+}
+
+func (c *counter) Get() interface{} {
+	return *c.p
+}
+
+//TypeName describes the type in usage
+func (c *counter) TypeName() string {
+	return "count"
+}
+
+//IsBool is true because a counter does not need a value after the option
+func (c *counter) IsBool() bool {
+	return true
+}
+
+func (c *counter) clone() Value {
+	value := *c.p
+	return &counter{p: &value, max: c.max}
+}
+
+func (c *counter) save() interface{} {
+	return *c.p
+}
+
+func (c *counter) restore(saved interface{}) {
+	*c.p = saved.(int)
+}
+
+func (c *counter) reset() {
+	*c.p = 0
+}
+
+//increment counts one more occurrence of the option
+func (c *counter) increment() error {
+	if c.max > 0 && *c.p >= c.max {
+		return fmt.Errorf("may not be specified more than %d times", c.max)
+	}
+	*c.p++
+	return nil
+} //counter.increment()
+
+//setPresent sets a flag that was specified without a value from the specified
+//source: a bool flag becomes true and a counter counts one more,
+//where the first count from a source replaces counts from sources of lower precedence
+func (f *FlagDescription) setPresent(src source) error {
+	c, ok := f.value.(*counter)
+	if !ok {
+		return f.setFrom(src, "true")
+	}
+	if f.source < src {
+		c.reset()
+	}
+	if err := c.increment(); err != nil {
+		return f.invalid(c.String(), err, fmt.Sprintf("%n %v", f, err))
+	}
+	if err := f.validateValue(c.String()); err != nil {
+		return err
+	}
+	f.source = src
+	return nil
+} //FlagDescription.setPresent()
+
+//repeatedCounter returns the counter flag for each time it is repeated in opt,
+//e.g. -v three times for "-vvv", or nil when opt is not a repeated counter
+func (set *Set) repeatedCounter(opt string) []*FlagDescription {
+	if len(opt) < 3 || !strings.HasPrefix(opt, "-") || strings.Trim(opt[1:], opt[1:2]) != "" {
+		return nil
+	}
+	f, found := set.shortFlag(opt[:2])
+	if !found {
+		return nil
+	}
+	if _, isCounter := f.value.(*counter); !isCounter {
+		return nil
+	}
+	repeated := make([]*FlagDescription, len(opt)-1)
+	for i := range repeated {
+		repeated[i] = f
+	}
+	return repeated
+} //Set.repeatedCounter()
@@ -0,0 +1,63 @@
+package flags
+
+import (
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	runParseTests(t, []parseTest{
+		{
+			name:      "GNU counter cluster",
+			syntax:    GNU,
+			args:      []string{"-vvd", "-v"},
+			remaining: []string{},
+			values:    map[string]string{"-v": "3", "-d": "true"},
+		},
+		{
+			name:      "counter takes only an attached value",
+			args:      []string{"-v", "2", "--verbose=2"},
+			remaining: []string{"2"},
+			values:    map[string]string{"-v": "2"},
+		},
+		{
+			name:   "counter beyond max",
+			syntax: GNU,
+			args:   []string{"-vvvv"},
+			err:    "may not be specified more than 3 times",
+		},
+		{
+			name:      "repeated counter without GNU syntax",
+			args:      []string{"-vv", "-v"},
+			remaining: []string{},
+			values:    map[string]string{"-v": "3"},
+		},
+		{
+			name:      "counter cluster with other options needs GNU syntax",
+			args:      []string{"-vd"},
+			remaining: []string{"-vd"},
+			values:    map[string]string{"-v": "0", "-d": "false"},
+		},
+		{
+			name: "repeated counter beyond max",
+			args: []string{"-vvvv"},
+			err:  "may not be specified more than 3 times",
+		},
+		{
+			name:      "explicit count resets",
+			args:      []string{"-vv", "--verbose=0", "-v"},
+			remaining: []string{},
+			values:    map[string]string{"-v": "1"},
+		},
+		{
+			name: "explicit count beyond max",
+			args: []string{"--verbose=4"},
+			err:  "count may not be more than 3",
+		},
+		{
+			name:      "repeated bool is not a counter",
+			args:      []string{"-dd"},
+			remaining: []string{"-dd"},
+			values:    map[string]string{"-d": "false"},
+		},
+	})
+} //TestCounter()
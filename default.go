@@ -1,9 +1,10 @@
 package flags
 
 import (
+	"encoding"
 	"fmt"
+	"io"
 	"os"
-	"path"
 )
 
 var (
@@ -28,6 +29,94 @@ func Int(short, long string, init int, doc string) *FlagDescription {
 	return newFlagPtr
 } //Int()
 
+//StringList in the default set
+func StringList(short, long string, init []string, doc string) *FlagDescription {
+	return mustDefine(defaultSet.StringList(short, long, init, doc))
+} //StringList()
+
+//IntList in the default set
+func IntList(short, long string, init []int, doc string) *FlagDescription {
+	return mustDefine(defaultSet.IntList(short, long, init, doc))
+} //IntList()
+
+//Map in the default set
+func Map(short, long string, init map[string]string, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Map(short, long, init, doc))
+} //Map()
+
+//Counter in the default set
+func Counter(short, long string, max int, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Counter(short, long, max, doc))
+} //Counter()
+
+//Text in the default set
+func Text(short, long string, ptr encoding.TextUnmarshaler, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Text(short, long, ptr, doc))
+} //Text()
+
+//Int64 in the default set
+func Int64(short, long string, init int64, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Int64(short, long, init, doc))
+} //Int64()
+
+//Int32 in the default set
+func Int32(short, long string, init int32, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Int32(short, long, init, doc))
+} //Int32()
+
+//Int16 in the default set
+func Int16(short, long string, init int16, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Int16(short, long, init, doc))
+} //Int16()
+
+//Int8 in the default set
+func Int8(short, long string, init int8, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Int8(short, long, init, doc))
+} //Int8()
+
+//Uint in the default set
+func Uint(short, long string, init uint, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Uint(short, long, init, doc))
+} //Uint()
+
+//Uint64 in the default set
+func Uint64(short, long string, init uint64, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Uint64(short, long, init, doc))
+} //Uint64()
+
+//Uint32 in the default set
+func Uint32(short, long string, init uint32, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Uint32(short, long, init, doc))
+} //Uint32()
+
+//Uint16 in the default set
+func Uint16(short, long string, init uint16, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Uint16(short, long, init, doc))
+} //Uint16()
+
+//Uint8 in the default set
+func Uint8(short, long string, init uint8, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Uint8(short, long, init, doc))
+} //Uint8()
+
+//Float64 in the default set
+func Float64(short, long string, init float64, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Float64(short, long, init, doc))
+} //Float64()
+
+//Float32 in the default set
+func Float32(short, long string, init float32, doc string) *FlagDescription {
+	return mustDefine(defaultSet.Float32(short, long, init, doc))
+} //Float32()
+
+//mustDefine returns the new flag, or panics on error
+func mustDefine(newFlagPtr *FlagDescription, err error) *FlagDescription {
+	if err != nil {
+		panic(fmt.Sprintf("Failed to define flag: %v", err))
+	}
+	return newFlagPtr
+} //mustDefine()
+
 //String in the default set
 func String(short, long string, init string, doc string) *FlagDescription {
 	newFlagPtr, err := defaultSet.String(short, long, init, doc)
@@ -37,6 +126,52 @@ func String(short, long string, init string, doc string) *FlagDescription {
 	return newFlagPtr
 } //String()
 
+//Define adds a flag of type T to the default set and returns a typed handle to it
+//e.g. limit := flags.Define("-l", "--limit", 2, "Limit nr of records")
+func Define[T Scalar](short, long string, init T, doc string) *TypedFlag[T] {
+	newFlag, err := Var(defaultSet, short, long, init, doc)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to define flag: %v", err))
+	}
+	return newFlag
+} //Define()
+
+//Arg adds a required positional argument to the default set
+func Arg(name string, init interface{}, doc string) *FlagDescription {
+	newArgPtr, err := defaultSet.Arg(name, init, doc)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to define argument: %v", err))
+	}
+	return newArgPtr
+} //Arg()
+
+//OptionalArg adds an optional positional argument to the default set
+func OptionalArg(name string, init interface{}, doc string) *FlagDescription {
+	newArgPtr, err := defaultSet.OptionalArg(name, init, doc)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to define argument: %v", err))
+	}
+	return newArgPtr
+} //OptionalArg()
+
+//Args adds a variadic positional argument to the default set
+func Args(name string, min, max int, init interface{}, doc string) *FlagDescription {
+	newArgPtr, err := defaultSet.Args(name, min, max, init, doc)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to define argument: %v", err))
+	}
+	return newArgPtr
+} //Args()
+
+//Config adds a flag to the default set naming a JSON file to load flag values from
+func Config(short, long string, doc string) *FlagDescription {
+	newFlagPtr, err := defaultSet.Config(short, long, doc)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to define flag: %v", err))
+	}
+	return newFlagPtr
+} //Config()
+
 //AddSet adds the specified set to the default set, and panic on error
 func AddSet(otherSet Set) {
 	err := defaultSet.AddSet(otherSet)
@@ -45,6 +180,83 @@ func AddSet(otherSet Set) {
 	}
 } //AddSet()
 
+//SetInterspersed controls if options may follow positional values in the default set
+func SetInterspersed(interspersed bool) {
+	defaultSet.SetInterspersed(interspersed)
+} //SetInterspersed()
+
+//SetSyntax selects the syntax variations accepted by the default set
+func SetSyntax(syntax Syntax) {
+	defaultSet.SetSyntax(syntax)
+} //SetSyntax()
+
+//SetEnvPrefix binds all long options in the default set to environment variables
+//named with the prefix, e.g. with prefix "APP" --log-level uses $APP_LOG_LEVEL
+func SetEnvPrefix(prefix string) {
+	defaultSet.SetEnvPrefix(prefix)
+} //SetEnvPrefix()
+
+//MutuallyExclusive flags in the default set
+func MutuallyExclusive(names ...string) {
+	mustConstrain(defaultSet.MutuallyExclusive(names...))
+} //MutuallyExclusive()
+
+//Requires other flags in the default set when the named flag is specified
+func Requires(name string, required ...string) {
+	mustConstrain(defaultSet.Requires(name, required...))
+} //Requires()
+
+//AtLeastOne of the flags in the default set must be specified
+func AtLeastOne(names ...string) {
+	mustConstrain(defaultSet.AtLeastOne(names...))
+} //AtLeastOne()
+
+//mustConstrain panics when a constraint could not be added to the default set
+func mustConstrain(err error) {
+	if err != nil {
+		panic(fmt.Sprintf("Failed to define constraint: %v", err))
+	}
+} //mustConstrain()
+
+//SetNormalizer canonicalizes option names in the default set, e.g. flags.SetNormalizer(flags.NormalizeName)
+func SetNormalizer(normalizer func(name string) string) {
+	if err := defaultSet.SetNormalizer(normalizer); err != nil {
+		panic(fmt.Sprintf("Failed to set normalizer: %v", err))
+	}
+} //SetNormalizer()
+
+//SetDescription sets paragraphs of text written in usage of the default set
+func SetDescription(text string) {
+	defaultSet.SetDescription(text)
+} //SetDescription()
+
+//AddExample adds an example invocation to usage of the default set
+func AddExample(command, doc string) {
+	defaultSet.AddExample(command, doc)
+} //AddExample()
+
+//SetFooter sets notes written at the end of usage of the default set
+func SetFooter(text string) {
+	defaultSet.SetFooter(text)
+} //SetFooter()
+
+//GenerateManPages writes man pages for the default set to dir, named after the command
+//because the default set has no name, e.g. flags.GenerateManPages("man", "app")
+func GenerateManPages(dir string, name string) error {
+	return defaultSet.GenerateManPages(dir, name)
+} //GenerateManPages()
+
+//GenerateMarkdown writes a Markdown reference for the default set to dir,
+//named after the command because the default set has no name
+func GenerateMarkdown(dir string, name string) error {
+	return defaultSet.GenerateMarkdown(dir, name)
+} //GenerateMarkdown()
+
+//WriteHTML writes a single page HTML reference for the default set, named after the command
+func WriteHTML(w io.Writer, name string) error {
+	return defaultSet.WriteHTML(w, name)
+} //WriteHTML()
+
 //DefaultSet to get read access to the default set
 func DefaultSet() Set {
 	return *defaultSet
@@ -55,8 +267,7 @@ func Usage(errorMsg string) {
 	if errorMsg != "" {
 		fmt.Fprintf(os.Stderr, "ERROR: %s\n", errorMsg)
 	}
-	fmt.Fprintf(os.Stderr, "Usage: %s [options]\n", path.Base(os.Args[0]))
-	defaultSet.PrintUsage(os.Stderr)
+	defaultSet.WriteHelp(os.Stderr)
 	os.Exit(-1)
 } //Usage()
 
@@ -69,6 +280,9 @@ func Parse() {
 	}
 	//if "?" is specified or --help, display usage info without an error
 	for _, opt := range os.Args[1:] {
+		if opt == "--" {
+			break
+		}
 		if opt == "?" || opt == "--help" {
 			Usage("")
 		}
@@ -89,6 +303,9 @@ func ParseKnown() []string {
 	}
 	//if "?" is specified or --help, display usage info without an error
 	for _, opt := range os.Args[1:] {
+		if opt == "--" {
+			break
+		}
 		if opt == "?" || opt == "--help" {
 			Usage("")
 		}
@@ -0,0 +1,253 @@
+package flags
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//docRow describes a flag or positional argument in a reference table
+type docRow struct {
+	id          string
+	usage       string
+	typeName    string
+	defaultText string
+	constraints string
+	doc         string
+	commands    []*helpCommand
+}
+
+//docRows describes the flags for a reference table of the command, with the
+//commands of the options of Group flags
+func docRows(c *helpCommand, flags []HelpFlag) []docRow {
+	rows := make([]docRow, 0, len(flags))
+	for _, f := range flags {
+		name := f.Name
+		if name == "" {
+			name = f.Long
+		}
+		if name == "" {
+			name = f.Short
+		}
+		row := docRow{
+			id:          c.name + "--" + strings.TrimLeft(name, "-"),
+			usage:       f.Usage,
+			typeName:    f.Type,
+			defaultText: f.Default,
+			doc:         f.Doc,
+		}
+		constraints := make([]string, 0, 2)
+		if len(f.Allowed) > 0 {
+			constraints = append(constraints, "one of "+strings.Join(f.Allowed, ", "))
+		}
+		if notes := strings.TrimSpace(f.Notes); notes != "" {
+			constraints = append(constraints, notes)
+		}
+		if f.Env != "" {
+			row.doc += " [$" + f.Env + "]"
+		}
+		row.constraints = strings.Join(constraints, " ")
+		for _, option := range f.Options {
+			for _, child := range c.children {
+				if child.name == c.name+"-"+option.Name {
+					row.commands = append(row.commands, child)
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+} //docRows()
+
+//GenerateMarkdown writes a Markdown reference to dir for the set as <name>.md, and
+//for each option of its Group flags as <name>-<option>.md, with links between them,
+//named after the set if name is "", see Set.GenerateManPages() to use it with go generate
+func (set Set) GenerateMarkdown(dir string, name string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("Set.GenerateMarkdown() cannot create %s: %w", dir, err)
+	}
+	for _, c := range set.helpCommands(name) {
+		b := &strings.Builder{}
+		writeMarkdown(b, c)
+		filename := filepath.Join(dir, c.name+".md")
+		if err := os.WriteFile(filename, []byte(b.String()), 0644); err != nil {
+			return fmt.Errorf("Set.GenerateMarkdown() cannot write %s: %w", filename, err)
+		}
+	}
+	return nil
+} //Set.GenerateMarkdown()
+
+//writeMarkdown writes the reference of a command in Markdown
+func writeMarkdown(b *strings.Builder, c *helpCommand) {
+	help := c.help
+	fmt.Fprintf(b, "# %s\n", markdown(c.name))
+	if c.parent != nil {
+		fmt.Fprintf(b, "\nOption of [%s](%s.md)\n", markdown(c.parent.name), c.parent.name)
+	}
+	if help.Doc != "" {
+		fmt.Fprintf(b, "\n%s\n", markdown(help.Doc))
+	}
+	if help.Description != "" {
+		fmt.Fprintf(b, "\n%s\n", markdown(help.Description))
+	}
+	fmt.Fprintf(b, "\n## Usage\n\n```\n%s\n```\n", strings.TrimSpace(c.command+" "+help.Synopsis))
+	for _, category := range helpCategories(help.Flags) {
+		heading := "Options"
+		if category.name != "" {
+			heading = category.name
+		}
+		fmt.Fprintf(b, "\n## %s\n\n", markdown(heading))
+		writeMarkdownTable(b, "Option", docRows(c, category.flags))
+	}
+	if len(help.Args) > 0 {
+		fmt.Fprintf(b, "\n## Arguments\n\n")
+		writeMarkdownTable(b, "Argument", docRows(c, help.Args))
+	}
+	if len(help.Constraints) > 0 {
+		fmt.Fprintf(b, "\n## Constraints\n\n")
+		for _, constraint := range help.Constraints {
+			fmt.Fprintf(b, "* %s\n", markdown(constraint))
+		}
+	}
+	if len(help.Examples) > 0 {
+		fmt.Fprintf(b, "\n## Examples\n")
+		for _, e := range help.Examples {
+			fmt.Fprintf(b, "\n```\n%s\n```\n", e.Command)
+			if e.Doc != "" {
+				fmt.Fprintf(b, "\n%s\n", markdown(e.Doc))
+			}
+		}
+	}
+	if len(c.children) > 0 {
+		fmt.Fprintf(b, "\n## Commands\n\n")
+		for _, child := range c.children {
+			fmt.Fprintf(b, "* [%s](%s.md) %s\n", markdown(child.name), child.name, markdown(child.help.Doc))
+		}
+	}
+	if help.Footer != "" {
+		fmt.Fprintf(b, "\n%s\n", markdown(help.Footer))
+	}
+} //writeMarkdown()
+
+//writeMarkdownTable writes a table of flags or arguments with an anchor for each
+func writeMarkdownTable(b *strings.Builder, heading string, rows []docRow) {
+	fmt.Fprintf(b, "| %s | Type | Default | Constraints | Description |\n", heading)
+	fmt.Fprintf(b, "|---|---|---|---|---|\n")
+	for _, row := range rows {
+		doc := markdownCell(row.doc)
+		for _, child := range row.commands {
+			doc += fmt.Sprintf(" [%s](%s.md)", markdownCell(strings.TrimPrefix(child.name, child.parent.name+"-")), child.name)
+		}
+		fmt.Fprintf(b, "| <a id=\"%s\"></a>`%s` | %s | %s | %s | %s |\n",
+			row.id,
+			strings.ReplaceAll(row.usage, "|", "\\|"),
+			markdownCell(row.typeName),
+			markdownCell(row.defaultText),
+			markdownCell(row.constraints),
+			strings.TrimSpace(doc))
+	}
+} //writeMarkdownTable()
+
+//markdown escapes text for Markdown
+func markdown(text string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\", "|", "\\|", "*", "\\*", "_", "\\_", "`", "\\`",
+		"[", "\\[", "]", "\\]", "<", "&lt;", ">", "&gt;", "&", "&amp;",
+	).Replace(text)
+} //markdown()
+
+//markdownCell escapes text for a Markdown table cell, which must be on one line
+func markdownCell(text string) string {
+	return strings.Join(strings.Fields(markdown(text)), " ")
+} //markdownCell()
+
+//WriteHTML writes a reference of the set and the options of its Group flags on a
+//single HTML page, with an anchor for each command and flag and links between them,
+//named after the set if name is ""
+func (set Set) WriteHTML(w io.Writer, name string) error {
+	commands := set.helpCommands(name)
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n", html.EscapeString(commands[0].name))
+	for _, c := range commands {
+		writeHTMLCommand(b, c)
+	}
+	fmt.Fprintf(b, "</body>\n</html>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+} //Set.WriteHTML()
+
+//writeHTMLCommand writes the section of a command on the HTML page
+func writeHTMLCommand(b *strings.Builder, c *helpCommand) {
+	help := c.help
+	fmt.Fprintf(b, "<section id=\"%s\">\n<h1>%s</h1>\n", html.EscapeString(c.name), html.EscapeString(c.name))
+	if c.parent != nil {
+		fmt.Fprintf(b, "<p>Option of <a href=\"#%s\">%s</a></p>\n", html.EscapeString(c.parent.name), html.EscapeString(c.parent.name))
+	}
+	for _, text := range append([]string{help.Doc}, strings.Split(help.Description, "\n\n")...) {
+		if text = strings.TrimSpace(text); text != "" {
+			fmt.Fprintf(b, "<p>%s</p>\n", html.EscapeString(text))
+		}
+	}
+	fmt.Fprintf(b, "<h2>Usage</h2>\n<pre>%s</pre>\n", html.EscapeString(strings.TrimSpace(c.command+" "+help.Synopsis)))
+	for _, category := range helpCategories(help.Flags) {
+		heading := "Options"
+		if category.name != "" {
+			heading = category.name
+		}
+		fmt.Fprintf(b, "<h2>%s</h2>\n", html.EscapeString(heading))
+		writeHTMLTable(b, "Option", docRows(c, category.flags))
+	}
+	if len(help.Args) > 0 {
+		fmt.Fprintf(b, "<h2>Arguments</h2>\n")
+		writeHTMLTable(b, "Argument", docRows(c, help.Args))
+	}
+	if len(help.Constraints) > 0 {
+		fmt.Fprintf(b, "<h2>Constraints</h2>\n<ul>\n")
+		for _, constraint := range help.Constraints {
+			fmt.Fprintf(b, "<li>%s</li>\n", html.EscapeString(constraint))
+		}
+		fmt.Fprintf(b, "</ul>\n")
+	}
+	if len(help.Examples) > 0 {
+		fmt.Fprintf(b, "<h2>Examples</h2>\n")
+		for _, e := range help.Examples {
+			fmt.Fprintf(b, "<pre>%s</pre>\n", html.EscapeString(e.Command))
+			if e.Doc != "" {
+				fmt.Fprintf(b, "<p>%s</p>\n", html.EscapeString(e.Doc))
+			}
+		}
+	}
+	if len(c.children) > 0 {
+		fmt.Fprintf(b, "<h2>Commands</h2>\n<ul>\n")
+		for _, child := range c.children {
+			fmt.Fprintf(b, "<li><a href=\"#%s\">%s</a> %s</li>\n", html.EscapeString(child.name), html.EscapeString(child.name), html.EscapeString(child.help.Doc))
+		}
+		fmt.Fprintf(b, "</ul>\n")
+	}
+	if help.Footer != "" {
+		fmt.Fprintf(b, "<p>%s</p>\n", html.EscapeString(help.Footer))
+	}
+	fmt.Fprintf(b, "</section>\n")
+} //writeHTMLCommand()
+
+//writeHTMLTable writes a table of flags or arguments with an anchor for each
+func writeHTMLTable(b *strings.Builder, heading string, rows []docRow) {
+	fmt.Fprintf(b, "<table>\n<tr><th>%s</th><th>Type</th><th>Default</th><th>Constraints</th><th>Description</th></tr>\n", heading)
+	for _, row := range rows {
+		doc := html.EscapeString(row.doc)
+		for _, child := range row.commands {
+			doc += fmt.Sprintf(" <a href=\"#%s\">%s</a>", html.EscapeString(child.name), html.EscapeString(strings.TrimPrefix(child.name, child.parent.name+"-")))
+		}
+		fmt.Fprintf(b, "<tr id=\"%s\"><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(row.id),
+			html.EscapeString(row.usage),
+			html.EscapeString(row.typeName),
+			html.EscapeString(row.defaultText),
+			html.EscapeString(row.constraints),
+			strings.TrimSpace(doc))
+	}
+	fmt.Fprintf(b, "</table>\n")
+} //writeHTMLTable()
@@ -0,0 +1,60 @@
+package flags
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestGenerateMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	if err := newDocSet(t).GenerateMarkdown(dir, "app"); err != nil {
+		t.Fatalf("GenerateMarkdown() failed: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	if len(names) != 3 || names[0] != "app-add.md" || names[1] != "app-del.md" || names[2] != "app.md" {
+		t.Fatalf("generated %q, expected app.md, app-add.md and app-del.md", names)
+	}
+	for _, name := range names {
+		doc, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, name+".golden", doc)
+	}
+} //TestGenerateMarkdown()
+
+func TestWriteHTML(t *testing.T) {
+	b := &bytes.Buffer{}
+	if err := newDocSet(t).WriteHTML(b, ""); err != nil {
+		t.Fatalf("WriteHTML() failed: %v", err)
+	}
+	checkGolden(t, "test.html.golden", b.Bytes())
+} //TestWriteHTML()
+
+func TestMarkdown(t *testing.T) {
+	tests := map[string]string{
+		"plain text":    "plain text",
+		`a|b`:           `a\|b`,
+		"<all> & more":  "&lt;all&gt; &amp; more",
+		"*x* _y_ `z`":   "\\*x\\* \\_y\\_ \\`z\\`",
+		`[link] \d`:     `\[link\] \\d`,
+		"two\n  lines":  "two lines",
+		" padded cell ": "padded cell",
+	}
+	for text, expected := range tests {
+		if got := markdownCell(text); got != expected {
+			t.Errorf("markdownCell(%q) = %q, expected %q", text, got, expected)
+		}
+	}
+} //TestMarkdown()
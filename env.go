@@ -0,0 +1,60 @@
+package flags
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+//SetEnv binds the flag to an environment variable, which is used when
+//the flag is not specified on the command line
+func (f *FlagDescription) SetEnv(name string) *FlagDescription {
+	f.env = name
+	return f
+} //FlagDescription.SetEnv()
+
+//SetEnvPrefix binds all long options in the set to environment variables
+//named with the prefix, e.g. with prefix "APP" --log-level uses $APP_LOG_LEVEL
+//Names set with FlagDescription.SetEnv() are not affected.
+//Group sets without their own prefix use the prefix of the set they are in.
+func (set *Set) SetEnvPrefix(prefix string) {
+	set.envPrefix = prefix
+} //Set.SetEnvPrefix()
+
+//envName returns the name of the environment variable for the flag, or ""
+func (f FlagDescription) envName(prefix string) string {
+	if f.env != "" {
+		return f.env
+	}
+	if prefix == "" || f.long == "" {
+		return ""
+	}
+	name := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(f.long[2:]))
+	return strings.TrimSuffix(prefix, "_") + "_" + name
+} //FlagDescription.envName()
+
+//applyEnv sets flags that were not specified on the command line from the
+//environment, using the same parsing and validation as command line values
+//Environment values replace values from config files.
+func (set *Set) applyEnv(prefix string) error {
+	if set.envPrefix != "" {
+		prefix = set.envPrefix
+	}
+	for _, flag := range set.flags {
+		if flag.source < sourceEnv {
+			if name := flag.envName(prefix); name != "" {
+				if valueString, ok := os.LookupEnv(name); ok {
+					if err := flag.setFrom(sourceEnv, valueString); err != nil {
+						return fmt.Errorf("$%s: %w", name, err)
+					}
+				}
+			}
+		}
+		if selected := flag.Selected(); selected != nil {
+			if err := selected.applyEnv(prefix); err != nil {
+				return fmt.Errorf("%n %s: %w", flag, flag.value, err)
+			}
+		}
+	} //for each flag
+	return nil
+} //Set.applyEnv()
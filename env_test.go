@@ -0,0 +1,62 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSourcePrecedence(t *testing.T) {
+	t.Setenv("TEST_TAG", "env")
+	t.Setenv("TEST_VERBOSE", "2")
+	t.Setenv("TEST_LIMIT", "9")
+	tests := []struct {
+		args   []string
+		values map[string]string
+	}{
+		{
+			args:   []string{},
+			values: map[string]string{"-t": "[env]", "-v": "2", "-l": "9"},
+		},
+		{
+			args:   []string{"-t", "a", "-t", "b", "-v", "-l", "1"},
+			values: map[string]string{"-t": "[a b]", "-v": "1", "-l": "1"},
+		},
+	}
+	for _, test := range tests {
+		set := newTestSet(t)
+		set.SetEnvPrefix("TEST")
+		if err := set.Parse(test.args); err != nil {
+			t.Fatalf("Parse(%q) failed: %v", test.args, err)
+		}
+		for name, expected := range test.values {
+			if got := fmt.Sprintf("%v", set.Flag(name).Value()); got != expected {
+				t.Errorf("Parse(%q) %s=%s, expected %s", test.args, name, got, expected)
+			}
+		}
+	}
+} //TestSourcePrecedence()
+
+func TestEnv(t *testing.T) {
+	t.Setenv("TEST_USER", "joe")
+	t.Setenv("DEBUG", "true")
+	set := newTestSet(t)
+	set.SetEnvPrefix("TEST_")
+	set.long["--debug"].SetEnv("DEBUG")
+	if err := set.Parse([]string{"-o", "add"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if got := values(set, []string{"-u", "-d"}); got["-u"] != "joe" || got["-d"] != "true" {
+		t.Errorf("got %v, expected -u=joe from $TEST_USER and -d=true from $DEBUG", got)
+	}
+
+	t.Setenv("TEST_LIMIT", "x")
+	set = newTestSet(t)
+	set.SetEnvPrefix("TEST")
+	if err := set.Parse([]string{}); err == nil || !strings.HasPrefix(err.Error(), "$TEST_LIMIT: ") {
+		t.Errorf("expected invalid $TEST_LIMIT, got %v", err)
+	}
+	if err := set.Parse([]string{"-l", "3"}); err != nil {
+		t.Errorf("expected $TEST_LIMIT to be ignored when -l is specified, got %v", err)
+	}
+} //TestEnv()
@@ -0,0 +1,143 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+)
+
+//ErrHelp is returned by Parse() when "--help" or "?" is specified but not defined
+var ErrHelp = errors.New("Help requested")
+
+//UnknownFlagError is returned by Parse() for options that are not defined in the set
+type UnknownFlagError struct {
+	//Arg is the first unknown option and Index its position in the parsed options
+	Arg   string
+	Index int
+	//Args are all the unknown options
+	Args []string
+	//Suggestions are the defined options closest to the unknown options
+	Suggestions []string
+}
+
+func (e *UnknownFlagError) Error() string {
+	return fmt.Sprintf("Unknown options: %v", e.Args) + didYouMean(e.Suggestions)
+} //UnknownFlagError.Error()
+
+//AmbiguousError is returned for an abbreviation that matches more than one
+//long option, or more than one Select value or Group option
+type AmbiguousError struct {
+	//Flag with the abbreviated value, or nil for an abbreviated option
+	Flag *FlagDescription
+	//Arg is the abbreviation and Index its position in the parsed options
+	Arg   string
+	Index int
+	//Candidates that Arg is a prefix of
+	Candidates []string
+}
+
+func (e *AmbiguousError) Error() string {
+	if e.Flag != nil {
+		return fmt.Sprintf("Ambiguous %n value \"%s\", could be %s", e.Flag, e.Arg, joinNames(e.Candidates, "or"))
+	}
+	return fmt.Sprintf("Ambiguous option %s, could be %s", e.Arg, joinNames(e.Candidates, "or"))
+} //AmbiguousError.Error()
+
+//InvalidValueError is returned when a flag value cannot be parsed or is not valid
+type InvalidValueError struct {
+	//Flag that the value was specified for
+	Flag *FlagDescription
+	//Value that is not valid
+	Value string
+	//Arg is the command line argument with the value, e.g. "--limit=x",
+	//and Index its position in the parsed options, or -1 when the value
+	//is not from the command line
+	Arg   string
+	Index int
+	//Err is the parse or validation error
+	Err error
+	//Suggestions are the allowed values closest to Value for Select and Group flags
+	Suggestions []string
+
+	message string
+}
+
+func (e *InvalidValueError) Error() string {
+	return e.message + didYouMean(e.Suggestions)
+} //InvalidValueError.Error()
+
+func (e *InvalidValueError) Unwrap() error {
+	return e.Err
+} //InvalidValueError.Unwrap()
+
+//MissingValueError is returned when an option that needs a value is the last argument
+type MissingValueError struct {
+	//Flag that needs a value
+	Flag *FlagDescription
+	//Arg is the option and Index its position in the parsed options
+	Arg   string
+	Index int
+}
+
+func (e *MissingValueError) Error() string {
+	return fmt.Sprintf("Expecting %s", e.Flag.expecting())
+} //MissingValueError.Error()
+
+//DuplicateFlagError is returned when adding a flag with an option that is already in the set
+type DuplicateFlagError struct {
+	//Option that is duplicated, e.g. "-l"
+	Option string
+	//Flag already in the set with the option
+	Flag *FlagDescription
+}
+
+func (e *DuplicateFlagError) Error() string {
+	if len(e.Option) == 2 {
+		if e.Flag != nil && e.Flag.short != e.Option {
+			return fmt.Sprintf("Duplicate short option %s, same as %s", e.Option, e.Flag.short)
+		}
+		return fmt.Sprintf("Duplicate short option %s", e.Option)
+	}
+	if e.Flag != nil && e.Flag.long != e.Option {
+		return fmt.Sprintf("Duplicate long option %s, same as %s", e.Option, e.Flag.long)
+	}
+	return fmt.Sprintf("Duplicate long option %s", e.Option)
+} //DuplicateFlagError.Error()
+
+//cmdArg is a command line argument and its position in the parsed options
+type cmdArg struct {
+	s     string
+	index int
+}
+
+//newCmdArgs numbers the options to parse
+func newCmdArgs(options []string) []cmdArg {
+	args := make([]cmdArg, len(options))
+	for i, s := range options {
+		args[i] = cmdArg{s: s, index: i}
+	}
+	return args
+} //newCmdArgs()
+
+//cmdArgStrings returns the text of the arguments
+func cmdArgStrings(args []cmdArg) []string {
+	strs := make([]string, len(args))
+	for i, a := range args {
+		strs[i] = a.s
+	}
+	return strs
+} //cmdArgStrings()
+
+//fromArg adds the command line argument to an InvalidValueError,
+//or its index to an AmbiguousError
+func fromArg(err error, a cmdArg) error {
+	var invalid *InvalidValueError
+	if errors.As(err, &invalid) && invalid.Index < 0 {
+		invalid.Arg = a.s
+		invalid.Index = a.index
+	}
+	var ambiguous *AmbiguousError
+	if errors.As(err, &ambiguous) && ambiguous.Index < 0 {
+		ambiguous.Index = a.index
+	}
+	return err
+} //fromArg()
@@ -0,0 +1,175 @@
+package flags
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		set    func(t *testing.T) *Set
+		syntax Syntax
+		args   []string
+		check  func(t *testing.T, err error)
+	}{
+		{
+			name: "invalid value in next argument",
+			set:  newTestSet,
+			args: []string{"-d", "-l", "x"},
+			check: func(t *testing.T, err error) {
+				var invalid *InvalidValueError
+				if !errors.As(err, &invalid) {
+					t.Fatalf("expected *InvalidValueError, got %v", err)
+				}
+				if invalid.Arg != "x" || invalid.Index != 2 || invalid.Value != "x" {
+					t.Errorf("got Arg=%q Index=%d Value=%q", invalid.Arg, invalid.Index, invalid.Value)
+				}
+			},
+		},
+		{
+			name: "invalid attached value",
+			set:  newTestSet,
+			args: []string{"--limit=x"},
+			check: func(t *testing.T, err error) {
+				var invalid *InvalidValueError
+				if !errors.As(err, &invalid) || invalid.Index != 0 || invalid.Value != "x" {
+					t.Fatalf("expected *InvalidValueError at 0, got %v", err)
+				}
+			},
+		},
+		{
+			name: "invalid value in a group keeps its index",
+			set:  newTestSet,
+			args: []string{"-d", "-o", "add", "-u", "joe", "-l", "x"},
+			check: func(t *testing.T, err error) {
+				var invalid *InvalidValueError
+				if !errors.As(err, &invalid) || invalid.Index != 6 {
+					t.Fatalf("expected *InvalidValueError at 6, got %v", err)
+				}
+			},
+		},
+		{
+			name: "missing value",
+			set:  newTestSet,
+			args: []string{"-d", "-l"},
+			check: func(t *testing.T, err error) {
+				var missing *MissingValueError
+				if !errors.As(err, &missing) || missing.Arg != "-l" || missing.Index != 1 {
+					t.Fatalf("expected *MissingValueError at 1, got %v", err)
+				}
+			},
+		},
+		{
+			name: "help",
+			set:  newTestSet,
+			args: []string{"-d", "?"},
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			},
+		},
+		{
+			name: "help in a group",
+			set:  newTestSet,
+			args: []string{"-o", "add", "--help"},
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			},
+		},
+		{
+			name: "help with missing positional arguments",
+			set:  newCopySet,
+			args: []string{"--help"},
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			},
+		},
+		{
+			name: "question mark is not a positional value",
+			set:  newCopySet,
+			args: []string{"a", "?"},
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			},
+		},
+		{
+			name: "unknown option with missing positional arguments",
+			set:  newCopySet,
+			args: []string{"--bogus"},
+			check: func(t *testing.T, err error) {
+				var unknown *UnknownFlagError
+				if !errors.As(err, &unknown) || unknown.Index != 0 {
+					t.Fatalf("expected *UnknownFlagError at 0, got %v", err)
+				}
+			},
+		},
+		{
+			name: "unexpected values",
+			set:  newTestSet,
+			args: []string{"-d", "file"},
+			check: func(t *testing.T, err error) {
+				if err == nil || err.Error() != "Unexpected arguments: [file]" {
+					t.Fatalf("expected unexpected arguments, got %v", err)
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := test.set(t)
+			set.SetSyntax(test.syntax)
+			test.check(t, set.Parse(test.args))
+		})
+	}
+} //TestParseErrors()
+
+func TestErrorMessages(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{args: []string{"-l", "x"}, expected: "Expecting -l <integer> or --limit=<integer>"},
+		{args: []string{"--limit=x"}, expected: "Expecting -l <integer> or --limit=<integer>"},
+		{args: []string{"-d", "-l"}, expected: "Expecting -l <integer> or --limit=<integer>"},
+		{args: []string{"--limit=99999999999999999999"}, expected: `-l value "99999999999999999999" is out of range for <integer>`},
+		{args: []string{"-c", "blue"}, expected: `-c value "blue" is not valid: -c --colour value "blue" must be one of [red green grey]`},
+		{args: []string{"-x", "--y"}, expected: "Unknown options: [-x --y]"},
+		{args: []string{"-d", "extra"}, expected: "Unexpected arguments: [extra]"},
+		{args: []string{"<n>"}, expected: "Unexpected arguments: [<n>]"},
+	}
+	for _, test := range tests {
+		err := newTestSet(t).Parse(test.args)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("Parse(%q) error %v, expected %q", test.args, err, test.expected)
+		}
+	}
+
+	set := NewSet("test", "Test set")
+	if _, err := set.Arg("n", 0, "Number"); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"x"}); err == nil || err.Error() != "Expecting <n> to be <integer>" {
+		t.Errorf("expected invalid <n>, got %v", err)
+	}
+} //TestErrorMessages()
+
+func TestDuplicateFlagError(t *testing.T) {
+	set := newTestSet(t)
+	var duplicate *DuplicateFlagError
+	_, err := set.Bool("-d", "--dump", false, "Dump")
+	if !errors.As(err, &duplicate) || duplicate.Option != "-d" || duplicate.Flag == nil || duplicate.Flag.long != "--debug" {
+		t.Errorf("expected duplicate -d, got %v", err)
+	}
+	_, err = set.Bool("", "--debug", false, "Debug")
+	if !errors.As(err, &duplicate) || duplicate.Option != "--debug" {
+		t.Errorf("expected duplicate --debug, got %v", err)
+	}
+} //TestDuplicateFlagError()
@@ -3,11 +3,13 @@
 //Run this with option ? to see usage, or make a mistake to see usage
 //    $ ./example1 ?
 //    Usage: example1 [options]
-//    -d	--debug 	false	Run in debug mode
-//    -e	--error 	false	Error stack dump
-//      	--input 	     	Input filename
-//    -o	--output	     	Output filename
-//    -l	--limit 	    2	Limit nr of records
+//
+//    Options:
+//      -d, --debug            Run in debug mode
+//      -e, --error            Error stack dump
+//          --input=<string>   Input filename
+//      -o, --output=<string>  Output filename
+//      -l, --limit=<integer>  Limit nr of records (default: 2)
 //
 //Run this with some option values:
 //    ./example1 --input=/tmp/in --output=/tmp/out -e -d false
@@ -44,7 +46,8 @@ func main() {
 	errorFlag := flags.Bool("-e", "--error", false, "Error stack dump")
 	flags.String("", "--input", "", "Input filename")
 	flags.String("-o", "--output", "", "Output filename")
-	flags.Int("-l", "--limit", 2, "Limit nr of records")
+	//or define a flag with a typed handle to get the value without type assertion:
+	limitFlag := flags.Define("-l", "--limit", 2, "Limit nr of records")
 
 	//now parse the command line options into those flags
 	//this is default parsing that will fail with panic()
@@ -63,7 +66,7 @@ func main() {
 	log.Printf("Error dump is %v", errorFlag.Value().(bool))
 
 	//or retrieve the value with the short or long option name and type assertion:
-	log.Printf("Limit=%d", flags.Flag("-l").Value().(int))
+	log.Printf("Limit=%d", limitFlag.Get())
 	log.Printf("Input=%s", flags.Flag("--input").Value().(string))
 	log.Printf("Output=%s", flags.Flag("--output").Value().(string))
 
@@ -3,6 +3,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -19,9 +20,23 @@ func main() {
 	//parse command line: we make a copy of default flag set, which will have log added to it
 	//then add all the registered operations to it:
 	flagSet := addOpersToFlagSet(flags.DefaultSet())
+	flagSet.AddExample("example3 -d -o add -n Joe", "Add Joe with debug logging")
+	flagSet.SetFooter("Log options are shared with other programs.")
 	if err := flagSet.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flags.ErrHelp) {
+			flagSet.WriteHelp(os.Stdout)
+			return
+		}
 		panic(fmt.Sprintf("Failed to parse: %v", err))
 	}
 
+	//the selected operation parsed its own options, e.g. -o add -n Joe
+	operFlag := flagSet.Flag("-o")
+	if operSet := operFlag.Selected(); operSet != nil {
+		log.Printf("Oper=%s with %+v\n", operFlag.Value().(string), *operSet)
+	} else {
+		log.Printf("No operation selected\n")
+	}
+
 	log.Printf("Success\n")
 } //main()
@@ -2,11 +2,11 @@ package flags
 
 import (
 	"fmt"
+	"io"
 	"log"
-	"os"
 	"regexp"
-	"strconv"
 	"strings"
+	"text/template"
 	"unicode"
 )
 
@@ -20,28 +20,84 @@ type group struct {
 	set  *Set
 }
 
+//source of a flag value, in order of precedence
+type source int
+
+const (
+	sourceDefault source = iota
+	sourceConfig
+	sourceEnv
+	sourceArgs
+)
+
 //FlagValueValidationFunc is called to validate the value
 type FlagValueValidationFunc func(value interface{}) error
 
 //FlagDescription ...
 type FlagDescription struct {
-	index     int
-	short     string
-	long      string
-	value     interface{}
-	specified bool
-	validate  FlagValueValidationFunc
-	group     map[string]group
-	doc       string
+	index        int
+	short        string
+	long         string
+	value        Value
+	defaultValue string
+	savedDefault interface{}
+	source       source
+	validate     FlagValueValidationFunc
+	validators   []Validator
+	allow        []string
+	group        map[string]group
+	doc          string
+	category     string
+	env          string
+	required     bool
+	requiredIf   []requiredIf
+
+	//bound values store into a variable of the caller, e.g. a struct field
+	//bound with Set.BindStruct(), so copies of the flag share the value
+	bound bool
+
+	//positional arguments have a name instead of short/long options
+	//and take min..max values (max<0 is unlimited)
+	name     string
+	min      int
+	max      int
+	variadic bool
 }
 
 //Set of flags
 type Set struct {
 	name  string
 	doc   string
-	flags []FlagDescription
+	flags []*FlagDescription
 	short map[string]*FlagDescription
 	long  map[string]*FlagDescription
+	args  []*FlagDescription
+
+	//nonInterspersed stops parsing options at the first positional value
+	nonInterspersed bool
+
+	//syntax enables command line syntax variations
+	syntax Syntax
+
+	//envPrefix names environment variables for long options
+	envPrefix string
+
+	//constraints between flags, checked after parsing
+	constraints []constraint
+
+	//normalizer canonicalizes option names, if not nil
+	normalizer func(name string) string
+
+	//description, examples and footer are written in help
+	description string
+	examples    []HelpExample
+	footer      string
+
+	//helpTemplate to write help with instead of the built-in layout, if not nil
+	helpTemplate *template.Template
+
+	//configFlag names a JSON file with values to load after parsing
+	configFlag *FlagDescription
 }
 
 //NewSet to create a new set
@@ -49,9 +105,10 @@ func NewSet(name, doc string) *Set {
 	return &Set{
 		name:  name,
 		doc:   doc,
-		flags: make([]FlagDescription, 0),
+		flags: make([]*FlagDescription, 0),
 		short: make(map[string]*FlagDescription),
 		long:  make(map[string]*FlagDescription),
+		args:  make([]*FlagDescription, 0),
 	}
 }
 
@@ -60,7 +117,7 @@ func NewSet(name, doc string) *Set {
 //long must be "--ABC" when ABC is a word consisting of 2 or more characters,
 //   starting with a letter or digit, followed by more letters, digits, dashes, dots or underscores
 //   and ending again with a letter or digit.
-func newFlag(short, long string, value interface{}, validateFunc FlagValueValidationFunc, doc string) (FlagDescription, error) {
+func newFlag(short, long string, value Value, validateFunc FlagValueValidationFunc, doc string) (FlagDescription, error) {
 	if short != "" && !shortValidationPattern.MatchString(short) {
 		return FlagDescription{}, fmt.Errorf("Short option %s must be \"-<letter|digit>\"", short)
 	}
@@ -68,12 +125,12 @@ func newFlag(short, long string, value interface{}, validateFunc FlagValueValida
 		return FlagDescription{}, fmt.Errorf("Long option %s must be \"--<word>\" that starts and ends with a letters or digits and allows '_', '-' and '.' in the middle", long)
 	}
 	f := FlagDescription{
-		short:     short,
-		long:      long,
-		value:     value,
-		specified: false,
-		validate:  validateFunc,
-		doc:       doc,
+		short:    short,
+		long:     long,
+		value:    value,
+		source:   sourceDefault,
+		validate: validateFunc,
+		doc:      doc,
 	}
 	return f, nil
 } //newFlag()
@@ -85,14 +142,14 @@ func (set *Set) Bool(short, long string, init bool, doc string) (*FlagDescriptio
 	}
 	//create the new flag
 	value := init
-	newFlag, err := newFlag(short, long, value, nil, doc)
+	newFlag, err := newFlag(short, long, newScalar(&value), nil, doc)
 	if err != nil {
-		return nil, fmt.Errorf("Set.Bool() cannot add %s %s: %v", short, long, err)
+		return nil, fmt.Errorf("Set.Bool() cannot add %s %s: %w", short, long, err)
 	}
 	//add
 	newFlagPtr, err := set.Add(newFlag)
 	if err != nil {
-		return nil, fmt.Errorf("Set.Bool() cannot add %s %s: %v", short, long, err)
+		return nil, fmt.Errorf("Set.Bool() cannot add %s %s: %w", short, long, err)
 	}
 	return newFlagPtr, nil
 } //Set.Bool()
@@ -104,14 +161,14 @@ func (set *Set) Int(short, long string, init int, doc string) (*FlagDescription,
 	}
 	//create the new flag
 	value := init
-	newFlag, err := newFlag(short, long, value, nil, doc)
+	newFlag, err := newFlag(short, long, newScalar(&value), nil, doc)
 	if err != nil {
-		return nil, fmt.Errorf("Set.Int() cannot add %s %s: %v", short, long, err)
+		return nil, fmt.Errorf("Set.Int() cannot add %s %s: %w", short, long, err)
 	}
 	//add
 	newFlagPtr, err := set.Add(newFlag)
 	if err != nil {
-		return nil, fmt.Errorf("Set.Int() cannot add %s %s: %v", short, long, err)
+		return nil, fmt.Errorf("Set.Int() cannot add %s %s: %w", short, long, err)
 	}
 	return newFlagPtr, nil
 } //Set.Int()
@@ -123,14 +180,14 @@ func (set *Set) String(short, long string, init string, doc string) (*FlagDescri
 	}
 	//create the new flag
 	value := init
-	newFlag, err := newFlag(short, long, value, nil, doc)
+	newFlag, err := newFlag(short, long, newScalar(&value), nil, doc)
 	if err != nil {
-		return nil, fmt.Errorf("Set.String() cannot add %s %s: %v", short, long, err)
+		return nil, fmt.Errorf("Set.String() cannot add %s %s: %w", short, long, err)
 	}
 	//add
 	newFlagPtr, err := set.Add(newFlag)
 	if err != nil {
-		return nil, fmt.Errorf("Set.String() cannot add %s %s: %v", short, long, err)
+		return nil, fmt.Errorf("Set.String() cannot add %s %s: %w", short, long, err)
 	}
 	return newFlagPtr, nil
 } //Set.String()
@@ -145,7 +202,7 @@ func (set *Set) Select(short, long string, init string, allow []string, doc stri
 	newFlag, err := newFlag(
 		short,
 		long,
-		value,
+		newScalar(&value),
 		func([]string) FlagValueValidationFunc {
 			return func(value interface{}) error {
 				for _, s := range allow {
@@ -158,12 +215,13 @@ func (set *Set) Select(short, long string, init string, allow []string, doc stri
 		}(allow),
 		doc)
 	if err != nil {
-		return nil, fmt.Errorf("Set.Select() cannot add %s %s: %v", short, long, err)
+		return nil, fmt.Errorf("Set.Select() cannot add %s %s: %w", short, long, err)
 	}
+	newFlag.allow = append([]string{}, allow...)
 	//add
 	newFlagPtr, err := set.Add(newFlag)
 	if err != nil {
-		return nil, fmt.Errorf("Set.Select() cannot add %s %s: %v", short, long, err)
+		return nil, fmt.Errorf("Set.Select() cannot add %s %s: %w", short, long, err)
 	}
 	return newFlagPtr, nil
 } //Set.Select()
@@ -180,19 +238,19 @@ func (set *Set) Group(short, long string, doc string) (*FlagDescription, error)
 	newFlag, err := newFlag(
 		short,
 		long,
-		value,
+		newScalar(&value),
 		nil, //will use newFlag.validateGroupSelect,
 		doc)
 	if err != nil {
-		return nil, fmt.Errorf("Set.Select() cannot add %s %s: %v", short, long, err)
+		return nil, fmt.Errorf("Set.Select() cannot add %s %s: %w", short, long, err)
 	}
 	//add
 	newFlagPtr, err := set.Add(newFlag)
 	if err != nil {
-		return nil, fmt.Errorf("Set.Select() cannot add %s %s: %v", short, long, err)
+		return nil, fmt.Errorf("Set.Select() cannot add %s %s: %w", short, long, err)
 	}
 	newFlagPtr.group = make(map[string]group)
-	newFlagPtr.validate = newFlag.validateGroupSelect
+	newFlagPtr.validate = newFlagPtr.validateGroupSelect
 	return newFlagPtr, nil
 } //Set.Group()
 
@@ -246,86 +304,210 @@ func (set *Set) Add(flag FlagDescription) (*FlagDescription, error) {
 	if flag.doc == "" {
 		return nil, fmt.Errorf("Flag without documentation")
 	}
-	if flag.short != "" {
-		if _, ok := set.short[flag.short]; ok {
-			return nil, fmt.Errorf("Duplicate short option %s", flag.short)
-		}
-	}
-	if flag.long != "" {
-		if _, ok := set.long[flag.long]; ok {
-			return nil, fmt.Errorf("Duplicate long option %s", flag.long)
-		}
-	}
+	//keep a pointer to a separate copy, so the pointers we hand out remain
+	//valid when set.flags grows and are updated when the set is parsed
 	flag.index = len(set.flags)
-	set.flags = append(set.flags, flag)
-	newFlagPtr := &set.flags[len(set.flags)-1]
-	if flag.short != "" {
-		set.short[flag.short] = newFlagPtr
+	if !flag.Specified() {
+		flag.defaultValue = flag.value.String()
+		if r, ok := flag.value.(restorer); ok {
+			flag.savedDefault = r.save()
+		}
 	}
-	if flag.long != "" {
-		set.long[flag.long] = newFlagPtr
+	newFlagPtr := &flag
+	if err := set.addOptions(newFlagPtr); err != nil {
+		return nil, err
 	}
+	set.flags = append(set.flags, newFlagPtr)
 	return newFlagPtr, nil
 } //Set.Add()
 
 //AddSet copies all flags from the specified set to be in this set too
 //(but copied will have their own values, so parsing this set won't update
-// values in the otherSet)
+// values in the otherSet, except for values added with Set.Var() or Set.Text()
+// and values bound to struct fields with Set.BindStruct() that are shared)
+//Copied flags without a category get the name of the other set as category,
+//or its doc if it has no name, so help lists them under that heading.
 func (set *Set) AddSet(otherSet Set) error {
 	if set == nil {
 		return fmt.Errorf("(nil).AddSet")
 	}
 	updated := *set
+	copies := make(map[*FlagDescription]*FlagDescription, len(otherSet.flags))
 	for _, flag := range otherSet.flags {
-		if _, err := updated.Add(flag); err != nil {
-			return fmt.Errorf("Cannot copy all flags: %v", err)
+		copied := *flag
+		if c, ok := flag.value.(cloner); ok && !flag.bound {
+			copied.value = c.clone()
 		}
+		if copied.category == "" {
+			copied.category = otherSet.name
+			if copied.category == "" {
+				copied.category = otherSet.doc
+			}
+		}
+		copiedPtr, err := updated.Add(copied)
+		if err != nil {
+			return fmt.Errorf("Cannot copy all flags: %w", err)
+		}
+		copies[flag] = copiedPtr
+	}
+	//flags required with an option of a copied group flag require it with the copy
+	for flag, copiedPtr := range copies {
+		copiedPtr.requiredIf = make([]requiredIf, 0, len(flag.requiredIf))
+		for _, r := range flag.requiredIf {
+			if copiedGroup, ok := copies[r.group]; ok {
+				r.group = copiedGroup
+			}
+			copiedPtr.requiredIf = append(copiedPtr.requiredIf, r)
+		}
+	}
+	//a copied config flag loads its file into this set, unless it has its own
+	if otherSet.configFlag != nil && updated.configFlag == nil {
+		updated.configFlag = copies[otherSet.configFlag]
+	}
+	//constraints apply to the copied flags
+	for _, c := range otherSet.constraints {
+		copied := constraint{kind: c.kind, flags: make([]*FlagDescription, 0, len(c.flags))}
+		for _, flag := range c.flags {
+			copied.flags = append(copied.flags, copies[flag])
+		}
+		updated.constraints = append(updated.constraints, copied)
 	}
 	*set = updated
 	return nil
 } //Set.AddSet()
 
-//Flag to return a flag description
+//Flag to return a flag description by short/long option or positional argument name
 func (set Set) Flag(n string) FlagDescription {
-	flag, ok := set.short[n]
+	flag, ok := set.shortFlag(n)
 	if !ok {
-		flag, ok = set.long[n]
+		flag, ok = set.longFlag(n)
 		if !ok {
+			for _, arg := range set.args {
+				if arg.name == n {
+					return *arg
+				}
+			}
 			return FlagDescription{}
 		}
 	}
 	return *flag
 } //Set.Flag()
 
-//Parse and return error if found any unknown options
+//Parse and return error if found any unknown options or unexpected arguments,
+//and then if any required flags were not specified, naming all of them
+//Arguments after "--" are never options, so they are only unexpected when
+//there are no positional arguments to take them
+//Help requests and unknown options are reported before missing or extra
+//positional arguments, so "--help" works for commands with required arguments.
 func (set *Set) Parse(options []string) error {
-	remainingArgs, err := set.ParseKnown(options)
+	remainingArgs, restArgs, argsErr, err := set.parseArgs(newCmdArgs(options))
 	if err != nil {
 		return err
 	}
+	var unknown *UnknownFlagError
+	for _, a := range remainingArgs {
+		if a.s == "--help" || a.s == "?" {
+			return ErrHelp
+		}
+		if isOption(a.s) {
+			if unknown == nil {
+				unknown = &UnknownFlagError{Arg: a.s, Index: a.index}
+			}
+			unknown.Args = append(unknown.Args, a.s)
+		}
+	}
+	if unknown != nil {
+		suggested := make(map[string]bool)
+		for _, opt := range unknown.Args {
+			for _, s := range set.suggestOptions(opt) {
+				if !suggested[s] {
+					unknown.Suggestions = append(unknown.Suggestions, s)
+					suggested[s] = true
+				}
+			}
+		}
+		return unknown
+	}
+	if argsErr != nil {
+		return argsErr
+	}
+	if err := set.applySources(); err != nil {
+		return err
+	}
+	remainingArgs = append(remainingArgs, restArgs...)
 	if len(remainingArgs) > 0 {
-		return fmt.Errorf("Unknown options: %v", remainingArgs)
+		return fmt.Errorf("Unexpected arguments: %v", cmdArgStrings(remainingArgs))
 	}
-	return nil
+	return set.check()
 } //Set.Parse()
 
+//SetInterspersed controls if options may follow positional values (the default)
+//When false, parsing stops at the first value that is not an option, POSIX style,
+//and that value and all that follows are treated like arguments after "--"
+func (set *Set) SetInterspersed(interspersed bool) {
+	set.nonInterspersed = !interspersed
+} //Set.SetInterspersed()
+
 //ParseKnown process all known arguments and return the remaining/unknown args
 //but return error on invalid arguments
+//When a Group option is selected, the arguments that follow are parsed by the
+//selected set first, and what it does not know is parsed by this set
+//When the set has positional arguments, all values that are not options are
+//assigned to them, and only those that could not be assigned are returned.
+//Parsing stops at "--", which is dropped, and the arguments after it are
+//not parsed as options but are returned untouched after the other remaining args
+//Flags not specified in options are then taken from the environment if bound to it,
+//or from the config file if the set has a Config flag.
+//Then it fails with all required flags that are still not specified,
+//or when a constraint between flags is broken.
 func (set *Set) ParseKnown(options []string) ([]string, error) {
-	remainingArgs := make([]string, 0)
+	remainingArgs, restArgs, argsErr, err := set.parseArgs(newCmdArgs(options))
+	if err == nil {
+		err = argsErr
+	}
+	if err == nil {
+		err = set.applySources()
+	}
+	if err == nil {
+		err = set.check()
+	}
+	return cmdArgStrings(append(remainingArgs, restArgs...)), err
+} //Set.ParseKnown()
+
+//parseArgs does the work for ParseKnown(), returning the remaining args
+//before the end of options separately from the unassigned args after it
+//Errors in positional arguments are returned in argsErr rather than err, for
+//the caller to report after help requests and unknown options in the remaining args.
+//"?" is never a positional value but remains, like "--help" when not defined.
+func (set *Set) parseArgs(options []cmdArg) (remainingArgs []cmdArg, restArgs []cmdArg, argsErr error, err error) {
+	remainingArgs = make([]cmdArg, 0)
+	argValues := make([]cmdArg, 0)
+	restArgs = make([]cmdArg, 0)
 	skip := 0
-	for i, opt := range options {
+	for i := 0; i < len(options); i++ {
+		opt := options[i].s
 		if skip > 0 {
 			skip--
 			continue
 		} //if option already parsed as value in previous loop
 
-		flag, ok := set.short[opt]
+		if opt == "--" {
+			//end of options: everything after it is handed back untouched
+			restArgs = append(append([]cmdArg{}, options[i+1:]...), restArgs...)
+			break
+		}
+
+		flag, ok := set.shortFlag(opt)
 		valueString := ""
+		valueArg := options[i] //argument with the value
+		hasValue := false      //true when a value was found
+		attached := false      //true when value is part of opt
 		if ok {
 			//found short option match, value in next opt element
 			if i < len(options)-1 {
-				valueString = options[i+1]
+				valueArg = options[i+1]
+				valueString = valueArg.s
+				hasValue = true
 				skip = 1
 			}
 		} else {
@@ -335,57 +517,131 @@ func (set *Set) ParseKnown(options []string) ([]string, error) {
 			dashDashWord := ss[0]
 			if len(ss) > 1 {
 				valueString = ss[1]
+				hasValue = true
+				attached = true
+			}
+			flag, ok = set.longFlag(dashDashWord)
+			if !ok {
+				//may be an abbreviated long option, e.g. "--verb" for "--verbose"
+				abbreviated, err := set.matchLong(dashDashWord)
+				if err != nil {
+					return remainingArgs, restArgs, nil, fromArg(err, options[i])
+				}
+				flag, ok = abbreviated, abbreviated != nil
+			}
+			if ok && len(ss) == 1 && set.syntax&LongSeparate != 0 && i < len(options)-1 {
+				//long option value in next opt element, e.g. "--limit 5"
+				valueArg = options[i+1]
+				valueString = valueArg.s
+				hasValue = true
+				skip = 1
+			}
+			if !ok {
+				//may be clustered short options and/or a short option with attached value
+				var cluster []*FlagDescription
+				if cluster, flag, valueString, ok = set.splitCluster(opt); ok {
+					for _, boolFlag := range cluster {
+						if err := boolFlag.setPresent(sourceArgs); err != nil {
+							return remainingArgs, restArgs, nil, fromArg(err, options[i])
+						}
+					}
+					if flag == nil {
+						continue
+					}
+					attached = valueString != ""
+					hasValue = attached
+					if valueString == "" && i < len(options)-1 {
+						//value in next opt element, e.g. "-dl 5"
+						valueArg = options[i+1]
+						valueString = valueArg.s
+						hasValue = true
+						skip = 1
+					}
+				}
+			}
+			if !ok && opt == "?" {
+				//help request: add to remain so that it is not a positional value
+				remainingArgs = append(remainingArgs, options[i])
+				skip = 0
+				continue
+			}
+			if !ok && set.nonInterspersed && !isOption(opt) {
+				//first value stops option parsing
+				restArgs = append(append([]cmdArg{}, options[i:]...), restArgs...)
+				break
+			}
+			if !ok && len(set.args) > 0 && !isOption(opt) {
+				//positional value: assigned after all options were parsed
+				argValues = append(argValues, options[i])
+				skip = 0
+				continue
 			}
-			flag, ok = set.long[dashDashWord]
 			if !ok {
 				//unknown option: add to remain and move on
-				remainingArgs = append(remainingArgs, opt)
+				remainingArgs = append(remainingArgs, options[i])
 				skip = 0
 				continue
 			}
 		} //if not short
 
-		switch v := flag.value.(type) {
-		case bool:
-			//if next option is "true" or "false", parse the value
-			if valueString == "true" {
-				flag.value = true
-			} else if valueString == "false" {
-				flag.value = false
-			} else {
+		if flag.isBool() {
+			//if next option is "true" or "false", parse the value,
+			//but a counter only takes a value attached with "=", e.g. --verbose=3
+			_, isCounter := flag.value.(*counter)
+			if (isCounter && !attached) || (!isCounter && valueString != "true" && valueString != "false") {
 				//not using next option as valueString
-				flag.value = true
 				skip = 0
+				if err := flag.setPresent(sourceArgs); err != nil {
+					return remainingArgs, restArgs, nil, fromArg(err, options[i])
+				}
+				continue
 			}
-			flag.specified = true
-		case int:
-			intValue, err := strconv.Atoi(valueString)
+		} else if !hasValue {
+			return remainingArgs, restArgs, nil, &MissingValueError{Flag: flag, Arg: opt, Index: options[i].index}
+		}
+		valueString, err = set.expandValue(flag, valueString)
+		if err != nil {
+			return remainingArgs, restArgs, nil, fromArg(err, valueArg)
+		}
+		if err := flag.setFrom(sourceArgs, valueString); err != nil {
+			return remainingArgs, restArgs, nil, fromArg(err, valueArg)
+		}
+
+		if flag.group != nil {
+			//selected group parse the rest of the options first,
+			//then we continue with what it did not know
+			selected := flag.group[valueString].set
+			groupRemainingArgs, groupRestArgs, groupArgsErr, err := selected.parseArgs(options[i+1+skip:])
 			if err != nil {
-				return remainingArgs, fmt.Errorf("Expecting %s <integer> or %s=<integer>", flag.short, flag.long)
+				return remainingArgs, restArgs, nil, fmt.Errorf("%n %s: %w", flag, valueString, err)
 			}
-			flag.value = intValue
-			flag.specified = true
-		case string:
-			flag.value = valueString
-			if flag.validate != nil {
-				if err := flag.validate(flag.value); err != nil {
-					return remainingArgs, fmt.Errorf("%n value \"%s\" is not valid: %v", flag, flag.value, err)
-				}
+			if groupArgsErr != nil && argsErr == nil {
+				argsErr = fmt.Errorf("%n %s: %w", flag, valueString, groupArgsErr)
 			}
-			flag.specified = true
-			/*if flag.group != nil {
-
-			}*/
-		default:
-			return remainingArgs, fmt.Errorf("Sorry, flags of type %T is not yet fully supported", v)
+			options = groupRemainingArgs
+			restArgs = append(groupRestArgs, restArgs...)
+			i = -1
+			skip = 0
 		}
-
-		//variable flag is local, we must update the flag in the set as well
-		set.flags[flag.index].value = flag.value
-		set.flags[flag.index].specified = flag.specified
 	} //for each option specified
-	return remainingArgs, nil
-} //Set.Parse()
+
+	if len(set.args) > 0 {
+		//values after the end of options are positional values too
+		//and what could not be assigned is returned in the same part
+		nrValues := len(argValues)
+		unassigned, err := set.assignArgs(append(argValues, restArgs...))
+		if err != nil && argsErr == nil {
+			argsErr = err
+		}
+		nrAssigned := nrValues + len(restArgs) - len(unassigned)
+		if nrAssigned < nrValues {
+			remainingArgs = append(remainingArgs, argValues[nrAssigned:nrValues]...)
+			nrAssigned = nrValues
+		}
+		restArgs = restArgs[nrAssigned-nrValues:]
+	}
+	return remainingArgs, restArgs, argsErr, nil
+} //Set.parseArgs()
 
 //Format to write the set into text
 func (set Set) Format(state fmt.State, c rune) {
@@ -396,44 +652,23 @@ func (set Set) Format(state fmt.State, c rune) {
 	state.Write([]byte(s))
 } //Set.Format()
 
-//PrintUsage prints all flags as one would normally print them in command line usage output
-func (set Set) PrintUsage(f *os.File) {
-	longLen := 0
-	valueLen := 0
-	for _, flag := range set.flags {
-		l := len(flag.long)
-		if l > longLen {
-			longLen = l
-		}
-		if flag.value != nil {
-			v := fmt.Sprintf("%v", flag.value)
-			vl := len(v)
-			if vl > valueLen {
-				valueLen = vl
-			}
-		}
-	} //for each flag
-
-	for _, flag := range set.flags {
-		fmt.Fprintf(f, "\t%s\t%-*.*s\t%*v\t%s\n",
-			flag.short,
-			longLen,
-			longLen,
-			flag.long,
-			valueLen,
-			flag.value,
-			flag.doc)
-	} //for each flag
-	return
+//PrintUsage prints the usage line, the flags with their defaults and the
+//options of groups, as one would normally print them in command line usage output
+//See WriteHelp() and SetHelpTemplate()
+func (set Set) PrintUsage(w io.Writer) {
+	set.WriteHelp(w)
 } //Set.PrintUsage()
 
 //Format to write the flag into text
 func (f FlagDescription) Format(state fmt.State, c rune) {
 	s := ""
 	S := ""
-	if f.short != "" {
+	if f.name != "" {
+		s = "<" + f.name + ">"
+		S = s
+	} else if f.short != "" {
 		s = f.short
-		S := f.short
+		S = f.short
 		if f.long != "" {
 			S += " (" + f.long + ")"
 		}
@@ -453,14 +688,30 @@ func (f FlagDescription) Format(state fmt.State, c rune) {
 
 //Value to get the parsed value of the flag
 func (f FlagDescription) Value() interface{} {
-	return f.value
+	if f.value == nil {
+		return nil
+	}
+	return f.get()
 } //FlagDescription.Value()
 
 //Specified to get the parsed value of the flag
 func (f FlagDescription) Specified() bool {
-	return f.specified
+	return f.source != sourceDefault
 } //FlagDescription.Specified()
 
+//Selected returns the set of the selected option in a Group flag,
+//or nil if this is not a Group flag or nothing was selected
+func (f FlagDescription) Selected() *Set {
+	if f.group == nil {
+		return nil
+	}
+	g, ok := f.group[f.value.String()]
+	if !ok {
+		return nil
+	}
+	return g.set
+} //FlagDescription.Selected()
+
 //return true if string consists only of alpha-numeric characters: 0-9,a-z,A-Z
 func onlyAlnum(s string) bool {
 	for i, c := range s {
@@ -0,0 +1,204 @@
+package flags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//newTestSet makes a set with flags of each kind and a group with two options
+func newTestSet(t *testing.T) *Set {
+	t.Helper()
+	set := NewSet("test", "Test set")
+	add := NewSet("add", "Add a user")
+	del := NewSet("del", "Delete a user")
+	must := func(_ *FlagDescription, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("cannot define flag: %v", err)
+		}
+	}
+	must(set.Bool("-d", "--debug", false, "Debug"))
+	must(set.Bool("-e", "--error", false, "Error"))
+	must(set.Bool("", "--dry-run", false, "Dry run"))
+	must(set.Int("-l", "--limit", 2, "Limit"))
+	must(set.Counter("-v", "--verbose", 3, "Verbose"))
+	must(set.StringList("-t", "--tag", nil, "Tags"))
+	must(set.Select("-c", "--colour", "red", []string{"red", "green", "grey"}, "Colour"))
+	must(add.String("-u", "--user", "", "User to add"))
+	must(del.String("-u", "--user", "", "User to delete"))
+	must(del.Bool("-f", "--force", false, "Force"))
+	oper, err := set.Group("-o", "--oper", "Operation")
+	if err != nil {
+		t.Fatalf("cannot define group: %v", err)
+	}
+	if err := oper.Add(add); err != nil {
+		t.Fatalf("cannot add option: %v", err)
+	}
+	if err := oper.Add(del); err != nil {
+		t.Fatalf("cannot add option: %v", err)
+	}
+	return set
+} //newTestSet()
+
+//values formats the values of the named flags in set and its selected groups
+func values(set *Set, names []string) map[string]string {
+	v := make(map[string]string)
+	for _, name := range names {
+		flag := set.Flag(name)
+		if flag.value == nil {
+			if oper := set.Flag("-o").Selected(); oper != nil {
+				flag = oper.Flag(name)
+			}
+		}
+		if flag.value != nil {
+			v[name] = fmt.Sprintf("%v", flag.Value())
+		}
+	}
+	return v
+} //values()
+
+//parseTest is a case for ParseKnown() on the set made by newTestSet
+type parseTest struct {
+	name            string
+	syntax          Syntax
+	nonInterspersed bool
+	args            []string
+	remaining       []string
+	values          map[string]string
+	err             string
+}
+
+//runParseTests runs ParseKnown() for each test and checks the remaining
+//args and the values of the named flags, or that it fails with the error
+func runParseTests(t *testing.T, tests []parseTest) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := newTestSet(t)
+			set.SetSyntax(test.syntax)
+			set.SetInterspersed(!test.nonInterspersed)
+			remaining, err := set.ParseKnown(test.args)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("ParseKnown(%q) error %v, expected %q", test.args, err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseKnown(%q) failed: %v", test.args, err)
+			}
+			if !reflect.DeepEqual(remaining, test.remaining) {
+				t.Errorf("ParseKnown(%q) remaining %q, expected %q", test.args, remaining, test.remaining)
+			}
+			names := make([]string, 0, len(test.values))
+			for name := range test.values {
+				names = append(names, name)
+			}
+			if got := values(set, names); !reflect.DeepEqual(got, test.values) {
+				t.Errorf("ParseKnown(%q) values %v, expected %v", test.args, got, test.values)
+			}
+		})
+	}
+} //runParseTests()
+
+func TestParseKnown(t *testing.T) {
+	runParseTests(t, []parseTest{
+		{
+			name:      "options",
+			args:      []string{"-d", "--limit=5", "-t", "a", "--tag=b"},
+			remaining: []string{},
+			values:    map[string]string{"-d": "true", "-e": "false", "-l": "5", "-t": "[a b]"},
+		},
+		{
+			name:      "bool value",
+			args:      []string{"-d", "false", "-e", "true"},
+			remaining: []string{},
+			values:    map[string]string{"-d": "false", "-e": "true"},
+		},
+		{
+			name:      "unknown options and values remain in order",
+			args:      []string{"-x", "-d", "file", "--y=1"},
+			remaining: []string{"-x", "file", "--y=1"},
+			values:    map[string]string{"-d": "true"},
+		},
+		{
+			name:      "group options parsed by the selected set",
+			args:      []string{"-o", "del", "-u", "joe", "-f"},
+			remaining: []string{},
+			values:    map[string]string{"-o": "del", "-u": "joe", "-f": "true"},
+		},
+		{
+			name:      "group continues with options of the parent set",
+			args:      []string{"-o", "add", "-u", "joe", "-d", "-l", "7"},
+			remaining: []string{},
+			values:    map[string]string{"-o": "add", "-u": "joe", "-d": "true", "-l": "7"},
+		},
+		{
+			name:      "options of the parent set before the group",
+			args:      []string{"-d", "-o", "add", "-x", "-u", "joe"},
+			remaining: []string{"-x"},
+			values:    map[string]string{"-d": "true", "-o": "add", "-u": "joe"},
+		},
+		{
+			name:      "options of another group option are unknown",
+			args:      []string{"-o", "add", "-f"},
+			remaining: []string{"-f"},
+			values:    map[string]string{"-o": "add"},
+		},
+	})
+} //TestParseKnown()
+
+func TestEndOfOptions(t *testing.T) {
+	runParseTests(t, []parseTest{
+		{
+			name:      "end of options",
+			args:      []string{"-d", "--", "-e", "-x"},
+			remaining: []string{"-e", "-x"},
+			values:    map[string]string{"-d": "true", "-e": "false"},
+		},
+		{
+			name:      "end of options in a group",
+			args:      []string{"-o", "del", "-f", "--", "-u", "x"},
+			remaining: []string{"-u", "x"},
+			values:    map[string]string{"-o": "del", "-f": "true", "-u": ""},
+		},
+		{
+			name:      "unknown before end of options",
+			args:      []string{"-x", "--", "-y"},
+			remaining: []string{"-x", "-y"},
+			values:    map[string]string{},
+		},
+		{
+			name:            "non-interspersed stops at first value",
+			nonInterspersed: true,
+			args:            []string{"-d", "file", "-e"},
+			remaining:       []string{"file", "-e"},
+			values:          map[string]string{"-d": "true", "-e": "false"},
+		},
+		{
+			name:      "interspersed continues after values",
+			args:      []string{"-d", "file", "-e"},
+			remaining: []string{"file"},
+			values:    map[string]string{"-d": "true", "-e": "true"},
+		},
+		{
+			name:            "non-interspersed with end of options",
+			nonInterspersed: true,
+			args:            []string{"-d", "--", "-e", "file"},
+			remaining:       []string{"-e", "file"},
+			values:          map[string]string{"-d": "true", "-e": "false"},
+		},
+	})
+} //TestEndOfOptions()
+
+func TestParseAfterEndOfOptions(t *testing.T) {
+	set := newTestSet(t)
+	err := set.Parse([]string{"-d", "--", "-e"})
+	if err == nil || err.Error() != "Unexpected arguments: [-e]" {
+		t.Fatalf("expected -e after -- to be unexpected, got %v", err)
+	}
+	if set.Flag("-e").Specified() {
+		t.Errorf("-e after -- was parsed as an option")
+	}
+} //TestParseAfterEndOfOptions()
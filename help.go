@@ -0,0 +1,409 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//HelpSet describes a set for help output, and is the data that a help
+//template is executed with, see Set.SetHelpTemplate()
+type HelpSet struct {
+	//Name of the set, or of the program for an unnamed set
+	Name string
+	//Doc describes the set in one line, and Description in paragraphs
+	Doc         string
+	Description string
+	//Synopsis of the command line, e.g. "[options] <src>... <dst>"
+	Synopsis string
+	//Flags are the options and Args the positional arguments
+	Flags []HelpFlag
+	Args  []HelpFlag
+	//Constraints between flags, e.g. "--input and --stdin are mutually exclusive"
+	Constraints []string
+	//Examples of invocations, and Footer notes written at the end
+	Examples []HelpExample
+	Footer   string
+	//Width to wrap text to
+	Width int
+}
+
+//HelpExample is an example invocation with a description
+type HelpExample struct {
+	Command string
+	Doc     string
+}
+
+//HelpFlag describes a flag or positional argument for help output
+type HelpFlag struct {
+	//Short and Long options of a flag, or Name of a positional argument
+	Short string
+	Long  string
+	Name  string
+	//Usage shows how it is specified, e.g. "-l, --limit=<integer>"
+	Usage string
+	//Type of the value, e.g. "integer"
+	Type string
+	//Doc describes the flag
+	Doc string
+	//Category to list the flag under, or "" for the general options
+	Category string
+	//Default value, or "" when none
+	Default string
+	//Allowed values of Select flags
+	Allowed []string
+	//Env is the environment variable to set it with, if any
+	Env string
+	//Notes describe validators and when it is required, e.g. "(1..100) (required)"
+	Notes string
+	//Options that can be selected in a Group flag
+	Options []HelpOption
+}
+
+//HelpOption describes an option of a Group flag for help output
+type HelpOption struct {
+	Name string
+	Doc  string
+	Set  HelpSet
+}
+
+//Text is the full description of the flag, with notes, allowed values,
+//default and environment variable
+func (f HelpFlag) Text() string {
+	text := f.Doc
+	if len(f.Allowed) > 0 {
+		text += " (one of " + strings.Join(f.Allowed, "|") + ")"
+	}
+	text += f.Notes
+	if f.Default != "" {
+		text += " (default: " + f.Default + ")"
+	}
+	if f.Env != "" {
+		text += " [$" + f.Env + "]"
+	}
+	return text
+} //HelpFlag.Text()
+
+//SetCategory sets the heading that the flag is listed under in help,
+//instead of the general options or the set it was copied from by Set.AddSet()
+func (f *FlagDescription) SetCategory(name string) *FlagDescription {
+	f.category = name
+	return f
+} //FlagDescription.SetCategory()
+
+//SetDescription sets paragraphs of text written in help after the doc of the set
+func (set *Set) SetDescription(text string) {
+	set.description = text
+} //Set.SetDescription()
+
+//AddExample adds an example invocation to help, e.g.
+//set.AddExample("cp -r src dst", "Copy the directory src to dst")
+func (set *Set) AddExample(command, doc string) {
+	set.examples = append(set.examples, HelpExample{Command: command, Doc: doc})
+} //Set.AddExample()
+
+//SetFooter sets notes written at the end of help, e.g. where to report bugs
+func (set *Set) SetFooter(text string) {
+	set.footer = text
+} //Set.SetFooter()
+
+//SetHelpTemplate sets a text/template to write help with instead of the
+//built-in layout, executed with a HelpSet, e.g. "{{.Name}}: {{.Doc}}"
+//Templates can call wrap with the indent and text to wrap it to the width.
+func (set *Set) SetHelpTemplate(text string) error {
+	if set == nil {
+		return fmt.Errorf("Set.SetHelpTemplate() called on set==nil")
+	}
+	if text == "" {
+		set.helpTemplate = nil
+		return nil
+	}
+	t, err := template.New("help").Funcs(template.FuncMap{"wrap": wrapFunc(80)}).Parse(text)
+	if err != nil {
+		return fmt.Errorf("Set.SetHelpTemplate() cannot parse template: %w", err)
+	}
+	set.helpTemplate = t
+	return nil
+} //Set.SetHelpTemplate()
+
+//WriteHelp writes the usage of the set, its flags and the options of its groups,
+//wrapped to the width of w when it is a terminal, see helpWidth()
+func (set Set) WriteHelp(w io.Writer) error {
+	help := set.helpFor(helpWidth(w))
+	if set.helpTemplate != nil {
+		t, err := set.helpTemplate.Clone()
+		if err != nil {
+			return err
+		}
+		return t.Funcs(template.FuncMap{"wrap": wrapFunc(help.Width)}).Execute(w, help)
+	}
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "Usage: %s\n", strings.TrimSpace(help.Name+" "+help.Synopsis))
+	if help.Doc != "" {
+		fmt.Fprintf(b, "\n%s\n", strings.Join(wrapText(help.Doc, help.Width), "\n"))
+	}
+	if help.Description != "" {
+		fmt.Fprintf(b, "\n%s\n", strings.Join(wrapText(help.Description, help.Width), "\n"))
+	}
+	writeHelpSet(b, help, 0)
+	if len(help.Examples) > 0 {
+		fmt.Fprintf(b, "\nExamples:\n")
+		for _, e := range help.Examples {
+			fmt.Fprintf(b, "  %s\n", e.Command)
+			for _, line := range wrapText(e.Doc, help.Width-6) {
+				if line != "" {
+					fmt.Fprintf(b, "      %s\n", line)
+				}
+			}
+		}
+	}
+	if help.Footer != "" {
+		fmt.Fprintf(b, "\n%s\n", strings.Join(wrapText(help.Footer, help.Width), "\n"))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+} //Set.WriteHelp()
+
+//Help describes the set for help output on stdout
+func (set Set) Help() HelpSet {
+	return set.helpFor(helpWidth(os.Stdout))
+} //Set.Help()
+
+//helpFor describes the set for help output wrapped to width
+func (set Set) helpFor(width int) HelpSet {
+	help := set.helpSet("", width)
+	if help.Name == "" && len(os.Args) > 0 {
+		help.Name = path.Base(os.Args[0])
+	}
+	return help
+} //Set.helpFor()
+
+//helpSet describes the set, with envPrefix inherited from the parent set
+func (set Set) helpSet(envPrefix string, width int) HelpSet {
+	if set.envPrefix != "" {
+		envPrefix = set.envPrefix
+	}
+	help := HelpSet{
+		Name:        set.name,
+		Doc:         set.doc,
+		Description: set.description,
+		Synopsis:    set.Synopsis(),
+		Flags:       make([]HelpFlag, 0, len(set.flags)),
+		Args:        make([]HelpFlag, 0, len(set.args)),
+		Examples:    set.examples,
+		Footer:      set.footer,
+		Width:       width,
+	}
+	for _, flag := range set.flags {
+		help.Flags = append(help.Flags, flag.help(envPrefix, width))
+	}
+	for _, arg := range set.args {
+		help.Args = append(help.Args, arg.help("", width))
+	}
+	for _, c := range set.constraints {
+		help.Constraints = append(help.Constraints, c.describe("%n"))
+	}
+	return help
+} //Set.helpSet()
+
+//help describes the flag for help output, with group options wrapped to width
+func (f FlagDescription) help(envPrefix string, width int) HelpFlag {
+	h := HelpFlag{
+		Short:    f.short,
+		Long:     f.long,
+		Name:     f.name,
+		Type:     f.typeName(),
+		Doc:      f.doc,
+		Category: f.category,
+		Default:  f.defaultValue,
+		Allowed:  f.allow,
+		Env:      f.envName(envPrefix),
+		Notes:    f.validatorUsage() + f.requiredUsage(),
+	}
+	switch h.Default {
+	case "false", "[]", "{}":
+		h.Default = ""
+	}
+	placeholder := "<" + h.Type + ">"
+	if f.group != nil {
+		placeholder = "<" + strings.Join(f.allowed(), "|") + ">"
+	}
+	options := make([]string, 0, 2)
+	if f.short != "" {
+		options = append(options, f.short)
+	}
+	if f.long != "" {
+		options = append(options, f.long)
+	}
+	h.Usage = strings.Join(options, ", ")
+	switch {
+	case f.name != "":
+		h.Usage = f.argUsage()
+	case f.isBool():
+	case f.long != "":
+		h.Usage += "=" + placeholder
+	default:
+		h.Usage += " " + placeholder
+	}
+	if f.group != nil {
+		for _, name := range f.allowed() {
+			g := f.group[name]
+			h.Options = append(h.Options, HelpOption{Name: name, Doc: g.set.doc, Set: g.set.helpSet(envPrefix, width)})
+		}
+	}
+	return h
+} //FlagDescription.help()
+
+//writeHelpSet writes the flags, arguments and constraints of a set, indented for group options
+//Flags with a category are written under a heading per category, after the general options.
+func writeHelpSet(b *strings.Builder, help HelpSet, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, category := range helpCategories(help.Flags) {
+		heading := "Options"
+		if category.name != "" {
+			heading = category.name
+		}
+		fmt.Fprintf(b, "\n%s%s:\n", pad, heading)
+		writeHelpFlags(b, category.flags, indent+2, help.Width)
+	}
+	if len(help.Args) > 0 {
+		fmt.Fprintf(b, "\n%sArguments:\n", pad)
+		writeHelpFlags(b, help.Args, indent+2, help.Width)
+	}
+	if len(help.Constraints) > 0 {
+		fmt.Fprintf(b, "\n%sConstraints:\n", pad)
+		for _, c := range help.Constraints {
+			fmt.Fprintf(b, "%s  %s\n", pad, c)
+		}
+	}
+} //writeHelpSet()
+
+//helpCategory is a list of flags written under one heading
+type helpCategory struct {
+	name  string
+	flags []HelpFlag
+}
+
+//helpCategories groups flags by category, starting with those without a category,
+//followed by the categories in the order that they first appear
+func helpCategories(flags []HelpFlag) []helpCategory {
+	categories := []helpCategory{{name: ""}}
+	index := map[string]int{"": 0}
+	for _, f := range flags {
+		i, ok := index[f.Category]
+		if !ok {
+			i = len(categories)
+			index[f.Category] = i
+			categories = append(categories, helpCategory{name: f.Category})
+		}
+		categories[i].flags = append(categories[i].flags, f)
+	}
+	if len(categories[0].flags) == 0 {
+		categories = categories[1:]
+	}
+	return categories
+} //helpCategories()
+
+//writeHelpFlags writes a line for each flag with its text wrapped in a column,
+//followed by the options of group flags
+func writeHelpFlags(b *strings.Builder, flags []HelpFlag, indent int, width int) {
+	names := make([]string, len(flags))
+	column := 0
+	for i, f := range flags {
+		names[i] = f.Usage
+		if f.Short == "" && f.Long != "" {
+			//align long options with those after a short option
+			names[i] = "    " + f.Usage
+		}
+		if l := len(names[i]) + 2; l > column {
+			column = l
+		}
+	}
+	if column > 30 {
+		column = 30
+	}
+	pad := strings.Repeat(" ", indent)
+	for i, f := range flags {
+		writeHelpLine(b, pad, names[i], f.Text(), column, width)
+		for _, option := range f.Options {
+			writeHelpLine(b, pad+"  ", option.Name, option.Doc, column-2, width)
+			if len(option.Set.Flags) > 0 || len(option.Set.Args) > 0 {
+				writeHelpFlags(b, option.Set.Flags, indent+4, width)
+				writeHelpFlags(b, option.Set.Args, indent+4, width)
+			}
+		}
+	}
+} //writeHelpFlags()
+
+//writeHelpLine writes the name padded to the column, followed by the wrapped text
+//Names that do not fit in the column are written on a line of their own.
+func writeHelpLine(b *strings.Builder, pad, name, text string, column int, width int) {
+	lines := wrapText(text, width-len(pad)-column)
+	if len(name) >= column {
+		fmt.Fprintf(b, "%s%s\n", pad, name)
+		name = ""
+	}
+	if len(lines) == 0 {
+		if name != "" {
+			fmt.Fprintf(b, "%s%s\n", pad, name)
+		}
+		return
+	}
+	fmt.Fprintf(b, "%s%-*s%s\n", pad, column, name, lines[0])
+	for _, line := range lines[1:] {
+		fmt.Fprintf(b, "%s%s%s\n", pad, strings.Repeat(" ", column), line)
+	}
+} //writeHelpLine()
+
+//wrapText splits text into lines of at most width characters, breaking between words,
+//and only longer lines for words that do not fit
+func wrapText(text string, width int) []string {
+	if width < 20 {
+		width = 20
+	}
+	lines := make([]string, 0)
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && len(line)+1+len(word) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		if line != "" || paragraph == "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+} //wrapText()
+
+//helpWidth is the width to wrap help written to w to: $COLUMNS if set, else the
+//width of the terminal when w is a file, or 80 when that is not a terminal
+//Writers that are not files, e.g. a buffer, always get 80 without $COLUMNS.
+func helpWidth(w io.Writer) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if file, ok := w.(*os.File); ok {
+		if width := terminalWidth(file); width > 0 {
+			return width
+		}
+	}
+	return 80
+} //helpWidth()
+
+//wrapFunc is the wrap function for help templates, to wrap text to width
+//after the indent
+func wrapFunc(width int) func(indent int, text string) string {
+	return func(indent int, text string) string {
+		return strings.Join(wrapText(text, width-indent), "\n"+strings.Repeat(" ", indent))
+	}
+} //wrapFunc()
@@ -0,0 +1,162 @@
+package flags
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+//checkGolden compares got with testdata/name, or writes it there with -update
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	filename := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("cannot read golden file (run go test -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("output differs from %s:\n%s\nexpected:\n%s", filename, got, expected)
+	}
+} //checkGolden()
+
+//newHelpSet makes the test set with an environment prefix, positional arguments,
+//notes from validators and required flags, and a constraint
+func newHelpSet(t *testing.T) *Set {
+	t.Helper()
+	set := newTestSet(t)
+	set.SetEnvPrefix("TEST")
+	set.long["--limit"].Validate(Range(1, 100))
+	name, err := set.String("-n", "--name", "", "Name of the user, written in the audit log of every operation that changes the user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name.Required()
+	if _, err := set.String("", "--a-very-long-option-name", "x", "Option too long for the column"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := set.Arg("src", "", "Source file"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := set.Args("dst", 0, -1, []string{}, "Destination files"); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.MutuallyExclusive("--debug", "--error"); err != nil {
+		t.Fatal(err)
+	}
+	return set
+} //newHelpSet()
+
+func TestWriteHelp(t *testing.T) {
+	for _, columns := range []string{"80", "50"} {
+		t.Run(columns, func(t *testing.T) {
+			t.Setenv("COLUMNS", columns)
+			b := &bytes.Buffer{}
+			if err := newHelpSet(t).WriteHelp(b); err != nil {
+				t.Fatalf("WriteHelp() failed: %v", err)
+			}
+			checkGolden(t, "help-"+columns+".golden", b.Bytes())
+		})
+	}
+} //TestWriteHelp()
+
+func TestHelpTemplate(t *testing.T) {
+	t.Setenv("COLUMNS", "30")
+	set := newHelpSet(t)
+	if err := set.SetHelpTemplate("{{.Name}}: {{.Doc}}\n{{range .Flags}}{{.Usage}}\n    {{wrap 4 .Text}}\n{{end}}"); err != nil {
+		t.Fatal(err)
+	}
+	b := &bytes.Buffer{}
+	if err := set.WriteHelp(b); err != nil {
+		t.Fatalf("WriteHelp() failed: %v", err)
+	}
+	checkGolden(t, "help-template.golden", b.Bytes())
+
+	if err := set.SetHelpTemplate("{{.Bogus"); err == nil {
+		t.Errorf("expected an invalid template to fail")
+	}
+	if err := set.SetHelpTemplate(""); err != nil || set.helpTemplate != nil {
+		t.Errorf("expected an empty template to restore the built-in layout, got %v", err)
+	}
+} //TestHelpTemplate()
+
+func TestHelpWidth(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "help"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	t.Setenv("COLUMNS", "")
+	if got := helpWidth(&bytes.Buffer{}); got != 80 {
+		t.Errorf("buffer width %d, expected 80", got)
+	}
+	if got := helpWidth(file); got != 80 {
+		t.Errorf("file width %d, expected 80", got)
+	}
+	t.Setenv("COLUMNS", "120")
+	if got := helpWidth(&bytes.Buffer{}); got != 120 {
+		t.Errorf("buffer width %d, expected $COLUMNS 120", got)
+	}
+	t.Setenv("COLUMNS", "wide")
+	if got := helpWidth(&bytes.Buffer{}); got != 80 {
+		t.Errorf("buffer width %d with invalid $COLUMNS, expected 80", got)
+	}
+} //TestHelpWidth()
+
+func TestWrapText(t *testing.T) {
+	got := wrapText("one two three four five six seven eight nine ten eleven\n\nextraordinarily-long-word x", 20)
+	expected := []string{"one two three four", "five six seven eight", "nine ten eleven", "", "extraordinarily-long-word", "x"}
+	if len(got) != len(expected) {
+		t.Fatalf("got %q, expected %q", got, expected)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Errorf("line %d %q, expected %q", i, got[i], expected[i])
+		}
+	}
+} //TestWrapText()
+
+func TestHelpSections(t *testing.T) {
+	t.Setenv("COLUMNS", "60")
+	set := NewSet("cp", "Copy files")
+	must := func(flag *FlagDescription, err error) *FlagDescription {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("cannot define flag: %v", err)
+		}
+		return flag
+	}
+	must(set.Bool("-r", "--recursive", false, "Copy directories recursively"))
+	must(set.Bool("-v", "--verbose", false, "Explain what is being done")).SetCategory("Output")
+	must(set.Bool("-f", "--force", false, "Overwrite existing files"))
+	must(set.String("", "--log", "", "Log file")).SetCategory("Output")
+	network := NewSet("network", "Network options")
+	must(network.String("", "--proxy", "", "Proxy URL"))
+	must(network.Int("", "--timeout", 30, "Seconds")).SetCategory("Timeouts")
+	if err := set.AddSet(*network); err != nil {
+		t.Fatal(err)
+	}
+	set.SetDescription("Copies each source to the destination, which must be a directory when there is more than one source.\n\nSymbolic links are copied as links.")
+	set.AddExample("cp -r src dst", "Copy the directory src to dst, including everything in it and in its subdirectories")
+	set.AddExample("cp a b dir", "")
+	set.SetFooter("Report bugs at https://example.com/bugs")
+
+	b := &bytes.Buffer{}
+	if err := set.WriteHelp(b); err != nil {
+		t.Fatalf("WriteHelp() failed: %v", err)
+	}
+	checkGolden(t, "help-sections.golden", b.Bytes())
+} //TestHelpSections()
@@ -0,0 +1,173 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+)
+
+//StringList adds a string list flag to the set, which appends a value
+//each time the option is specified, e.g. --tag a --tag b
+//The first value specified replaces the init values, as do values
+//from the environment or a config file.
+//Use SetSeparator() to also split values, e.g. --tag=a,b
+func (set *Set) StringList(short, long string, init []string, doc string) (*FlagDescription, error) {
+	return addList(set, "StringList", short, long, init, doc)
+} //Set.StringList()
+
+//IntList adds an integer list flag to the set, which appends a value
+//each time the option is specified, e.g. --port 80 --port 443
+//The first value specified replaces the init values, as do values
+//from the environment or a config file.
+//Use SetSeparator() to also split values, e.g. --port=80,443
+func (set *Set) IntList(short, long string, init []int, doc string) (*FlagDescription, error) {
+	return addList(set, "IntList", short, long, init, doc)
+} //Set.IntList()
+
+//addList adds a list flag with elements of type T to the set for the Set method with the given name
+func addList[T Scalar](set *Set, method string, short, long string, init []T, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.%s() called on set==nil", method)
+	}
+	//create the new flag
+	value := append([]T{}, init...)
+	newFlag, err := newFlag(short, long, newList(&value), nil, doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.%s() cannot add %s %s: %w", method, short, long, err)
+	}
+	//add
+	newFlagPtr, err := set.Add(newFlag)
+	if err != nil {
+		return nil, fmt.Errorf("Set.%s() cannot add %s %s: %w", method, short, long, err)
+	}
+	return newFlagPtr, nil
+} //addList()
+
+//SetSeparator sets the separator to split list values on, e.g. "," for --tag=a,b
+//and separators can be included in elements with quotes or '\', e.g. --tag='"a,b",c'
+//For map flags, it sets the separator between key and value, e.g. ":" for --header=K:V
+func (f *FlagDescription) SetSeparator(sep string) *FlagDescription {
+	if s, ok := f.value.(interface{ setSeparator(string) }); ok {
+		s.setSeparator(sep)
+	}
+	return f
+} //FlagDescription.SetSeparator()
+
+//list holds the values of a list flag or variadic argument
+type list[T Scalar] struct {
+	p *[]T
+	//sep to split values on, if not ""
+	sep string
+	//nrAdded is the nr of elements added by the last Set
+	nrAdded int
+}
+
+//newList makes a value that appends to *p
+func newList[T Scalar](p *[]T) *list[T] {
+	return &list[T]{p: p}
+} //newList()
+
+//Set parses s and appends it to the list, after splitting it if the list has a separator
+func (v *list[T]) Set(s string) error {
+	parts := []string{s}
+	if v.sep != "" {
+		var err error
+		if parts, err = splitList(s, v.sep); err != nil {
+			return err
+		}
+	}
+	elements := make([]T, len(parts))
+	for i, part := range parts {
+		if err := newScalar(&elements[i]).Set(part); err != nil {
+			return err
+		}
+	}
+	*v.p = append(*v.p, elements...)
+	v.nrAdded = len(elements)
+	return nil
+} //list.Set()
+
+//String formats the list as "[a,b]"
+func (v *list[T]) String() string {
+	elements := make([]string, len(*v.p))
+	for i, element := range *v.p {
+		elements[i] = fmt.Sprintf("%v", element)
+	}
+	return "[" + strings.Join(elements, ",") + "]"
+} //list.String()
+
+func (v *list[T]) Get() interface{} {
+	return *v.p
+}
+
+//TypeName describes the type of the elements in usage
+func (v *list[T]) TypeName() string {
+	return typeName[T]()
+}
+
+func (v *list[T]) clone() Value {
+	value := append([]T{}, *v.p...)
+	return &list[T]{p: &value, sep: v.sep}
+}
+
+func (v *list[T]) save() interface{} {
+	return append([]T{}, *v.p...)
+}
+
+func (v *list[T]) restore(saved interface{}) {
+	*v.p = append([]T{}, saved.([]T)...)
+}
+
+func (v *list[T]) setSeparator(sep string) {
+	v.sep = sep
+}
+
+//reset clears the list before new values are set
+func (v *list[T]) reset() {
+	*v.p = make([]T, 0)
+}
+
+//added returns the elements added by the last Set, to validate them one at a time
+func (v *list[T]) added() []interface{} {
+	elements := make([]interface{}, 0, v.nrAdded)
+	for _, element := range (*v.p)[len(*v.p)-v.nrAdded:] {
+		elements = append(elements, element)
+	}
+	return elements
+} //list.added()
+
+//quote escapes s so that Set adds it as a single element
+func (v *list[T]) quote(s string) string {
+	if v.sep == "" {
+		return s
+	}
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, v.sep, `\`+v.sep).Replace(s)
+} //list.quote()
+
+//splitList splits s on sep, except where sep is quoted with "..." or escaped with '\'
+func splitList(s string, sep string) ([]string, error) {
+	parts := make([]string, 0)
+	part := ""
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			if i == len(s)-1 {
+				return nil, fmt.Errorf("\"%s\" ends with '\\'", s)
+			}
+			i++
+			part += s[i : i+1]
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && strings.HasPrefix(s[i:], sep):
+			parts = append(parts, part)
+			part = ""
+			i += len(sep) - 1
+		default:
+			part += s[i : i+1]
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("\"%s\" has unterminated quotes", s)
+	}
+	return append(parts, part), nil
+} //splitList()
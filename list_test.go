@@ -0,0 +1,104 @@
+package flags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		s        string
+		sep      string
+		expected []string
+		err      string
+	}{
+		{s: "a,b", sep: ",", expected: []string{"a", "b"}},
+		{s: "a", sep: ",", expected: []string{"a"}},
+		{s: "", sep: ",", expected: []string{""}},
+		{s: "a,,b,", sep: ",", expected: []string{"a", "", "b", ""}},
+		{s: `"a,b",c`, sep: ",", expected: []string{"a,b", "c"}},
+		{s: `x"a,b"y,c`, sep: ",", expected: []string{"xa,by", "c"}},
+		{s: `a\,b,c`, sep: ",", expected: []string{"a,b", "c"}},
+		{s: `a\\,b`, sep: ",", expected: []string{`a\`, "b"}},
+		{s: `say \"hi\",bye`, sep: ",", expected: []string{`say "hi"`, "bye"}},
+		{s: "a::b:c", sep: "::", expected: []string{"a", "b:c"}},
+		{s: `"a::b"::c`, sep: "::", expected: []string{"a::b", "c"}},
+		{s: `"a,b`, sep: ",", err: "has unterminated quotes"},
+		{s: `a\`, sep: ",", err: "ends with '\\'"},
+	}
+	for _, test := range tests {
+		parts, err := splitList(test.s, test.sep)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("splitList(%s) error %v, expected %q", test.s, err, test.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(parts, test.expected) {
+			t.Errorf("splitList(%s) = %q, %v, expected %q", test.s, parts, err, test.expected)
+		}
+	}
+} //TestSplitList()
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name     string
+		sep      string
+		env      string
+		args     []string
+		expected string
+		err      string
+	}{
+		{name: "init", expected: "[80 443]"},
+		{name: "first value replaces init", args: []string{"-p", "1", "--port=2"}, expected: "[1 2]"},
+		{name: "split", sep: ",", args: []string{"--port=1,2", "-p", "3"}, expected: "[1 2 3]"},
+		{name: "not split without separator", args: []string{"--port=1,2"}, err: "Expecting"},
+		{name: "environment replaces init", sep: ",", env: "5,6", expected: "[5 6]"},
+		{name: "command line replaces environment", sep: ",", env: "5,6", args: []string{"-p", "7"}, expected: "[7]"},
+		{name: "invalid element", sep: ",", args: []string{"-p", "1,x"}, err: "Expecting"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.env != "" {
+				t.Setenv("TEST_PORT", test.env)
+			}
+			set := NewSet("test", "Test set")
+			set.SetEnvPrefix("TEST")
+			flag, err := set.IntList("-p", "--port", []int{80, 443}, "Ports")
+			if err != nil {
+				t.Fatal(err)
+			}
+			flag.SetSeparator(test.sep)
+			err = set.Parse(test.args)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("Parse(%q) error %v, expected %q", test.args, err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", test.args, err)
+			}
+			if got := fmt.Sprintf("%v", flag.Value()); got != test.expected {
+				t.Errorf("Parse(%q) = %s, expected %s", test.args, got, test.expected)
+			}
+		})
+	}
+} //TestList()
+
+func TestListQuote(t *testing.T) {
+	for _, sep := range []string{"", ",", "::"} {
+		var elements []string
+		l := newList(&elements)
+		l.setSeparator(sep)
+		element := `a,b::"c\`
+		if err := l.Set(l.quote(element)); err != nil {
+			t.Fatalf("sep %q: Set(quote(%s)) failed: %v", sep, element, err)
+		}
+		if !reflect.DeepEqual(elements, []string{element}) {
+			t.Errorf("sep %q: quoted element was added as %q", sep, elements)
+		}
+	}
+} //TestListQuote()
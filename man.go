@@ -0,0 +1,242 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//helpCommand is a set or a group option of it, documented as a command of its own,
+//e.g. "example3 -o add" is named "example3-add"
+type helpCommand struct {
+	name     string
+	command  string
+	help     HelpSet
+	parent   *helpCommand
+	children []*helpCommand
+}
+
+//helpCommands returns the command of the set followed by those of the options of its
+//Group flags, depth first, named after the command, or after the set if name is ""
+func (set Set) helpCommands(name string) []*helpCommand {
+	help := set.Help()
+	if name == "" {
+		name = help.Name
+	}
+	return addHelpCommands(nil, &helpCommand{name: name, command: name, help: help})
+} //Set.helpCommands()
+
+//addHelpCommands appends the command and the commands of its group options to list
+func addHelpCommands(list []*helpCommand, c *helpCommand) []*helpCommand {
+	list = append(list, c)
+	for _, f := range c.help.Flags {
+		selector := f.Short + " "
+		if f.Short == "" {
+			selector = f.Long + "="
+		}
+		for _, option := range f.Options {
+			child := &helpCommand{
+				name:    c.name + "-" + option.Name,
+				command: c.command + " " + selector + option.Name,
+				help:    option.Set,
+				parent:  c,
+			}
+			c.children = append(c.children, child)
+			list = addHelpCommands(list, child)
+		}
+	}
+	return list
+} //addHelpCommands()
+
+//WriteMan writes a section 1 man page in roff for the set and refers to pages of
+//its group options, named like "<name>-<option>", see Set.GenerateManPages()
+func (set Set) WriteMan(w io.Writer) error {
+	return writeMan(w, set.helpCommands("")[0])
+} //Set.WriteMan()
+
+//GenerateManPages writes section 1 man pages to dir for the set as <name>.1, and for
+//each option of its Group flags as <name>-<option>.1, named after the set if name is ""
+//As an unnamed set uses the name of the running program, specify the name when
+//generating pages from another program, e.g. with "//go:generate go run ./gendoc"
+//in a package that defines the flags, and gendoc calling set.GenerateManPages("man", "app").
+func (set Set) GenerateManPages(dir string, name string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("Set.GenerateManPages() cannot create %s: %w", dir, err)
+	}
+	for _, c := range set.helpCommands(name) {
+		b := &strings.Builder{}
+		if err := writeMan(b, c); err != nil {
+			return fmt.Errorf("Set.GenerateManPages() cannot write %s: %w", c.name, err)
+		}
+		filename := filepath.Join(dir, c.name+".1")
+		if err := os.WriteFile(filename, []byte(b.String()), 0644); err != nil {
+			return fmt.Errorf("Set.GenerateManPages() cannot write %s: %w", filename, err)
+		}
+	}
+	return nil
+} //Set.GenerateManPages()
+
+//writeMan writes the man page of a command
+//The page has no date, so that generated pages only change when the flags do.
+func writeMan(w io.Writer, c *helpCommand) error {
+	help := c.help
+	b := &strings.Builder{}
+	fmt.Fprintf(b, ".TH \"%s\" \"1\" \"\" \"\" \"User Commands\"\n", roff(strings.ToUpper(c.name)))
+	fmt.Fprintf(b, ".SH NAME\n%s", roff(c.name))
+	if help.Doc != "" {
+		fmt.Fprintf(b, " \\- %s", roff(help.Doc))
+	}
+	fmt.Fprintf(b, "\n.SH SYNOPSIS\n.B %s\n", roff(c.command))
+	if help.Synopsis != "" {
+		fmt.Fprintf(b, "%s\n", roff(help.Synopsis))
+	}
+	//the description repeats the doc from NAME only when there is no longer description
+	if description := help.Description; description != "" || help.Doc != "" {
+		if description == "" {
+			description = help.Doc
+		}
+		fmt.Fprintf(b, ".SH DESCRIPTION\n")
+		writeManParagraphs(b, description)
+	}
+	if len(help.Flags) > 0 {
+		fmt.Fprintf(b, ".SH OPTIONS\n")
+		for _, category := range helpCategories(help.Flags) {
+			if category.name != "" {
+				fmt.Fprintf(b, ".SS %s\n", roff(category.name))
+			}
+			for _, f := range category.flags {
+				writeManFlag(b, c, f)
+			}
+		}
+	}
+	if len(help.Args) > 0 {
+		fmt.Fprintf(b, ".SH ARGUMENTS\n")
+		for _, f := range help.Args {
+			writeManFlag(b, c, f)
+		}
+	}
+	if len(help.Constraints) > 0 {
+		fmt.Fprintf(b, ".SH CONSTRAINTS\n")
+		for _, constraint := range help.Constraints {
+			fmt.Fprintf(b, ".IP \\(bu 2\n%s\n", roff(constraint))
+		}
+	}
+	env := false
+	for _, f := range help.Flags {
+		if f.Env == "" {
+			continue
+		}
+		if !env {
+			fmt.Fprintf(b, ".SH ENVIRONMENT\n")
+			env = true
+		}
+		fmt.Fprintf(b, ".TP\n.B %s\nSets %s.\n", roff(f.Env), manOptions(f))
+	}
+	if len(help.Examples) > 0 {
+		fmt.Fprintf(b, ".SH EXAMPLES\n")
+		for _, e := range help.Examples {
+			fmt.Fprintf(b, ".TP\n.B %s\n%s\n", roff(e.Command), sentence(roff(e.Doc)))
+		}
+	}
+	if help.Footer != "" {
+		fmt.Fprintf(b, ".SH NOTES\n")
+		writeManParagraphs(b, help.Footer)
+	}
+	refs := make([]string, 0, len(c.children)+1)
+	if c.parent != nil {
+		refs = append(refs, "\\fB"+roff(c.parent.name)+"\\fR(1)")
+	}
+	for _, child := range c.children {
+		refs = append(refs, "\\fB"+roff(child.name)+"\\fR(1)")
+	}
+	if len(refs) > 0 {
+		fmt.Fprintf(b, ".SH SEE ALSO\n%s\n", strings.Join(refs, ",\n"))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+} //writeMan()
+
+//writeManFlag writes a tagged paragraph for a flag or positional argument, with
+//its type, allowed values, default and notes, and references to group options
+func writeManFlag(b *strings.Builder, c *helpCommand, f HelpFlag) {
+	fmt.Fprintf(b, ".TP\n%s\n", manOptions(f))
+	text := make([]string, 0, 4)
+	if f.Doc != "" {
+		text = append(text, sentence(roff(f.Doc)))
+	}
+	if f.Name == "" && len(f.Options) == 0 && strings.Contains(f.Usage, "<") {
+		text = append(text, "Type: "+roff(f.Type)+".")
+	}
+	if len(f.Allowed) > 0 {
+		text = append(text, "One of: "+roff(strings.Join(f.Allowed, ", "))+".")
+	}
+	if f.Default != "" {
+		text = append(text, "Default: "+roff(f.Default)+".")
+	}
+	if notes := strings.TrimSpace(f.Notes); notes != "" {
+		text = append(text, roff(notes))
+	}
+	if len(text) > 0 {
+		fmt.Fprintf(b, "%s\n", strings.Join(text, " "))
+	}
+	for _, option := range f.Options {
+		fmt.Fprintf(b, ".RS\n.TP\n.B %s\n", roff(option.Name))
+		if option.Doc != "" {
+			fmt.Fprintf(b, "%s\n", sentence(roff(option.Doc)))
+		}
+		fmt.Fprintf(b, "See \\fB%s\\fR(1).\n.RE\n", roff(c.name+"-"+option.Name))
+	}
+} //writeManFlag()
+
+//manOptions formats the options of a flag in bold and its value placeholder
+//in italics, e.g. "\fB\-l\fR, \fB\-\-limit\fR=\fIinteger\fR"
+func manOptions(f HelpFlag) string {
+	if f.Name != "" {
+		return "\\fI" + roff(f.Usage) + "\\fR"
+	}
+	options := make([]string, 0, 2)
+	if f.Short != "" {
+		options = append(options, "\\fB"+roff(f.Short)+"\\fR")
+	}
+	if f.Long != "" {
+		options = append(options, "\\fB"+roff(f.Long)+"\\fR")
+	}
+	s := strings.Join(options, ", ")
+	//the placeholder follows the options in usage, e.g. "=<integer>"
+	if i := strings.LastIndex(f.Usage, "<"); i > 0 {
+		s += f.Usage[i-1:i] + "\\fI" + roff(strings.TrimSuffix(f.Usage[i+1:], ">")) + "\\fR"
+	}
+	return s
+} //manOptions()
+
+//writeManParagraphs writes text with a paragraph for each block of lines
+func writeManParagraphs(b *strings.Builder, text string) {
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			fmt.Fprintf(b, ".PP\n%s\n", roff(paragraph))
+		}
+	}
+} //writeManParagraphs()
+
+//roff escapes text for roff, so that backslashes and dashes are printed as is
+//and lines do not start with a control character
+func roff(text string) string {
+	text = strings.NewReplacer("\\", "\\e", "-", "\\-").Replace(text)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = "\\&" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+} //roff()
+
+//sentence ends text with a full stop, unless it already ends with punctuation
+func sentence(text string) string {
+	if text == "" || strings.ContainsAny(text[len(text)-1:], ".!?:") {
+		return text
+	}
+	return text + "."
+} //sentence()
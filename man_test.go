@@ -0,0 +1,75 @@
+package flags
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+//newDocSet makes the help test set with a category, description, examples and
+//footer, and text that must be escaped
+func newDocSet(t *testing.T) *Set {
+	t.Helper()
+	set := newHelpSet(t)
+	set.long["--dry-run"].SetCategory("Safety")
+	if _, err := set.String("", "--pattern", `\d+`, ".Lines matching the pattern, e.g. -p 'a|b' <all> & more"); err != nil {
+		t.Fatal(err)
+	}
+	set.SetDescription("Manages users.\n\nEvery change is logged.")
+	set.AddExample("test -n joe -o add -u joe", "Add the user joe")
+	set.SetFooter("Report bugs at https://example.com/bugs")
+	return set
+} //newDocSet()
+
+func TestWriteMan(t *testing.T) {
+	b := &bytes.Buffer{}
+	if err := newDocSet(t).WriteMan(b); err != nil {
+		t.Fatalf("WriteMan() failed: %v", err)
+	}
+	checkGolden(t, "test.1.golden", b.Bytes())
+} //TestWriteMan()
+
+func TestGenerateManPages(t *testing.T) {
+	dir := t.TempDir()
+	if err := newDocSet(t).GenerateManPages(dir, "app"); err != nil {
+		t.Fatalf("GenerateManPages() failed: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	if len(names) != 3 || names[0] != "app-add.1" || names[1] != "app-del.1" || names[2] != "app.1" {
+		t.Fatalf("generated %q, expected app.1, app-add.1 and app-del.1", names)
+	}
+	for _, name := range names {
+		page, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, name+".golden", page)
+	}
+} //TestGenerateManPages()
+
+func TestRoff(t *testing.T) {
+	tests := map[string]string{
+		"plain text":  "plain text",
+		`a\b`:         `a\eb`,
+		"--limit":     `\-\-limit`,
+		".starts":     `\&.starts`,
+		"'quoted":     `\&'quoted`,
+		"mid.dle's":   "mid.dle's",
+		"line\n.next": "line\n\\&.next",
+	}
+	for text, expected := range tests {
+		if got := roff(text); got != expected {
+			t.Errorf("roff(%q) = %q, expected %q", text, got, expected)
+		}
+	}
+} //TestRoff()
@@ -0,0 +1,153 @@
+package flags
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//Map adds a map flag to the set, which adds a key/value pair each time the
+//option is specified, e.g. --label env=prod --label team=core
+//The first pair specified replaces the init pairs, as do pairs from the
+//environment or a config file.
+//Use SetSeparator() to change the key/value separator from "=", e.g. ":" for --header=K:V,
+//ValidateParts() to validate keys and values, and AllowDuplicateKeys() to
+//let a key specified again replace its value instead of failing.
+func (set *Set) Map(short, long string, init map[string]string, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.Map() called on set==nil")
+	}
+	//create the new flag
+	value := make(map[string]string, len(init))
+	for k, v := range init {
+		value[k] = v
+	}
+	newFlag, err := newFlag(short, long, newMapValue(&value), nil, doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Map() cannot add %s %s: %w", short, long, err)
+	}
+	//add
+	newFlagPtr, err := set.Add(newFlag)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Map() cannot add %s %s: %w", short, long, err)
+	}
+	return newFlagPtr, nil
+} //Set.Map()
+
+//ValidateParts sets functions to validate the keys and values of a map flag,
+//either may be nil
+func (f *FlagDescription) ValidateParts(validateKey, validateValue FlagValueValidationFunc) *FlagDescription {
+	if m, ok := f.value.(*mapValue); ok {
+		m.validateKey = validateKey
+		m.validateValue = validateValue
+	}
+	return f
+} //FlagDescription.ValidateParts()
+
+//AllowDuplicateKeys lets a key specified again in a map flag replace the value
+func (f *FlagDescription) AllowDuplicateKeys() *FlagDescription {
+	if m, ok := f.value.(*mapValue); ok {
+		m.allowDuplicates = true
+	}
+	return f
+} //FlagDescription.AllowDuplicateKeys()
+
+//mapValue holds the key/value pairs of a map flag
+type mapValue struct {
+	p               *map[string]string
+	sep             string
+	allowDuplicates bool
+	validateKey     FlagValueValidationFunc
+	validateValue   FlagValueValidationFunc
+}
+
+//newMapValue makes a value that adds to *p
+func newMapValue(p *map[string]string) *mapValue {
+	return &mapValue{p: p, sep: "="}
+} //newMapValue()
+
+//Set parses "key=value" and adds it to the map
+func (v *mapValue) Set(s string) error {
+	kv := strings.SplitN(s, v.sep, 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return fmt.Errorf("\"%s\" is not <%s>", s, v.TypeName())
+	}
+	if v.validateKey != nil {
+		if err := v.validateKey(kv[0]); err != nil {
+			return fmt.Errorf("key \"%s\" is not valid: %w", kv[0], err)
+		}
+	}
+	if v.validateValue != nil {
+		if err := v.validateValue(kv[1]); err != nil {
+			return fmt.Errorf("value \"%s\" is not valid: %w", kv[1], err)
+		}
+	}
+	if _, ok := (*v.p)[kv[0]]; ok && !v.allowDuplicates {
+		return fmt.Errorf("duplicate key \"%s\"", kv[0])
+	}
+	(*v.p)[kv[0]] = kv[1]
+	return nil
+} //mapValue.Set()
+
+//String formats the pairs sorted by key, e.g. "{env=prod,team=core}"
+func (v *mapValue) String() string {
+	keys := v.keys()
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + v.sep + (*v.p)[k]
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+} //mapValue.String()
+
+func (v *mapValue) Get() interface{} {
+	return *v.p
+}
+
+//TypeName describes the pairs in usage, e.g. "key=value"
+func (v *mapValue) TypeName() string {
+	return "key" + v.sep + "value"
+}
+
+func (v *mapValue) clone() Value {
+	value := make(map[string]string, len(*v.p))
+	for k, s := range *v.p {
+		value[k] = s
+	}
+	c := *v
+	c.p = &value
+	return &c
+}
+
+func (v *mapValue) save() interface{} {
+	saved := make(map[string]string, len(*v.p))
+	for k, s := range *v.p {
+		saved[k] = s
+	}
+	return saved
+}
+
+func (v *mapValue) restore(saved interface{}) {
+	*v.p = make(map[string]string, len(saved.(map[string]string)))
+	for k, s := range saved.(map[string]string) {
+		(*v.p)[k] = s
+	}
+}
+
+func (v *mapValue) setSeparator(sep string) {
+	v.sep = sep
+}
+
+//reset clears the map before new pairs are set
+func (v *mapValue) reset() {
+	*v.p = make(map[string]string)
+}
+
+//keys returns the keys in sorted order
+func (v *mapValue) keys() []string {
+	keys := make([]string, 0, len(*v.p))
+	for k := range *v.p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+} //mapValue.keys()
@@ -0,0 +1,87 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		sep        string
+		duplicates bool
+		args       []string
+		expected   string
+		err        string
+	}{
+		{name: "init", expected: "{env=dev,team=core}"},
+		{name: "first pair replaces init, sorted by key", args: []string{"-l", "z=1", "--label=a=2"}, expected: "{a=2,z=1}"},
+		{name: "value may contain the separator", args: []string{"-l", "url=a=b"}, expected: "{url=a=b}"},
+		{name: "empty value", args: []string{"-l", "env="}, expected: "{env=}"},
+		{name: "separator", sep: ":", args: []string{"-l", "accept:text/plain"}, expected: "{accept:text/plain}"},
+		{name: "duplicate key", args: []string{"-l", "env=prod", "-l", "env=test"}, err: `duplicate key "env"`},
+		{name: "duplicate key allowed", duplicates: true, args: []string{"-l", "env=prod", "-l", "env=test"}, expected: "{env=test}"},
+		{name: "missing separator", args: []string{"-l", "env"}, err: `"env" is not <key=value>`},
+		{name: "missing key", args: []string{"-l", "=prod"}, err: `"=prod" is not <key=value>`},
+		{name: "invalid key", args: []string{"-l", "Env=prod"}, err: `key "Env" is not valid: lower case only`},
+		{name: "invalid value", args: []string{"-l", "env=PROD"}, err: `value "PROD" is not valid: lower case only`},
+	}
+	lower := func(value interface{}) error {
+		if s := value.(string); s != strings.ToLower(s) {
+			return fmt.Errorf("lower case only")
+		}
+		return nil
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := NewSet("test", "Test set")
+			flag, err := set.Map("-l", "--label", map[string]string{"team": "core", "env": "dev"}, "Labels")
+			if err != nil {
+				t.Fatal(err)
+			}
+			flag.ValidateParts(lower, lower)
+			if test.sep != "" {
+				flag.SetSeparator(test.sep)
+			}
+			if test.duplicates {
+				flag.AllowDuplicateKeys()
+			}
+			err = set.Parse(test.args)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("Parse(%q) error %v, expected %q", test.args, err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", test.args, err)
+			}
+			if got := flag.value.String(); got != test.expected {
+				t.Errorf("Parse(%q) = %s, expected %s", test.args, got, test.expected)
+			}
+		})
+	}
+} //TestMap()
+
+func TestMapCopy(t *testing.T) {
+	other := NewSet("other", "Other set")
+	flag, err := other.Map("-l", "--label", map[string]string{"env": "dev"}, "Labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flag.SetSeparator(":")
+	set := NewSet("test", "Test set")
+	if err := set.AddSet(*other); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"-l", "team:core"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if got := set.Flag("-l").value.String(); got != "{team:core}" {
+		t.Errorf("copied map = %s, expected {team:core}", got)
+	}
+	if got := flag.value.String(); got != "{env:dev}" {
+		t.Errorf("original map = %s, expected {env:dev}", got)
+	}
+} //TestMapCopy()
@@ -0,0 +1,81 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+)
+
+//NormalizeName is a normalizer for Set.SetNormalizer() that makes long options
+//case insensitive and treats '_' and '.' like '-', so --Log_Level and --log.level
+//both match --log-level, while short options remain case sensitive
+func NormalizeName(name string) string {
+	if !strings.HasPrefix(name, "--") {
+		return name
+	}
+	return strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name))
+} //NormalizeName()
+
+//SetNormalizer sets a function that canonicalizes option names, so that options
+//match the flags they normalize to, e.g. set.SetNormalizer(flags.NormalizeName)
+//It fails with a *DuplicateFlagError when flags in the set normalize to the same
+//name, and then flags added later fail in the same way. Use nil to match names exactly.
+func (set *Set) SetNormalizer(normalizer func(name string) string) error {
+	if set == nil {
+		return fmt.Errorf("Set.SetNormalizer() called on set==nil")
+	}
+	updated := *set
+	updated.normalizer = normalizer
+	updated.short = make(map[string]*FlagDescription)
+	updated.long = make(map[string]*FlagDescription)
+	for _, flag := range set.flags {
+		if err := updated.addOptions(flag); err != nil {
+			return fmt.Errorf("Set.SetNormalizer() failed: %w", err)
+		}
+	}
+	*set = updated
+	return nil
+} //Set.SetNormalizer()
+
+//normalize returns the canonical form of an option name
+func (set Set) normalize(name string) string {
+	if set.normalizer == nil {
+		return name
+	}
+	return set.normalizer(name)
+} //Set.normalize()
+
+//addOptions adds the flag to the maps to look up its options by normalized name,
+//failing when an option is already in the set
+func (set *Set) addOptions(flag *FlagDescription) error {
+	short := set.normalize(flag.short)
+	long := set.normalize(flag.long)
+	if flag.short != "" {
+		if existing, ok := set.short[short]; ok {
+			return &DuplicateFlagError{Option: flag.short, Flag: existing}
+		}
+	}
+	if flag.long != "" {
+		if existing, ok := set.long[long]; ok {
+			return &DuplicateFlagError{Option: flag.long, Flag: existing}
+		}
+	}
+	if flag.short != "" {
+		set.short[short] = flag
+	}
+	if flag.long != "" {
+		set.long[long] = flag
+	}
+	return nil
+} //Set.addOptions()
+
+//shortFlag looks up a flag by short option
+func (set Set) shortFlag(opt string) (*FlagDescription, bool) {
+	flag, ok := set.short[set.normalize(opt)]
+	return flag, ok
+} //Set.shortFlag()
+
+//longFlag looks up a flag by long option
+func (set Set) longFlag(opt string) (*FlagDescription, bool) {
+	flag, ok := set.long[set.normalize(opt)]
+	return flag, ok
+} //Set.longFlag()
@@ -0,0 +1,149 @@
+package flags
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"--log-level": "--log-level",
+		"--log_level": "--log-level",
+		"--Log.Level": "--log-level",
+		"--LOG_LEVEL": "--log-level",
+		"-L":          "-L",
+		"-l":          "-l",
+		"":            "",
+	}
+	for name, expected := range tests {
+		if got := NormalizeName(name); got != expected {
+			t.Errorf("NormalizeName(%s) = %s, expected %s", name, got, expected)
+		}
+	}
+} //TestNormalizeName()
+
+func TestNormalizer(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalizer func(string) string
+		args       []string
+		level      string
+		err        string
+	}{
+		{name: "exact", normalizer: NormalizeName, args: []string{"--log-level=debug"}, level: "debug"},
+		{name: "underscores", normalizer: NormalizeName, args: []string{"--log_level=debug"}, level: "debug"},
+		{name: "dots and case", normalizer: NormalizeName, args: []string{"--Log.Level=debug"}, level: "debug"},
+		{name: "short option", normalizer: NormalizeName, args: []string{"-L", "debug"}, level: "debug"},
+		{name: "short option stays case sensitive", normalizer: NormalizeName, args: []string{"-l", "debug"}, err: "Unknown options: [-l]"},
+		{name: "normalizer removed", normalizer: nil, args: []string{"--Log.Level=debug"}, err: "Unknown options: [--Log.Level=debug], did you mean --log-level?"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := NewSet("test", "Test set")
+			if _, err := set.String("-L", "--log-level", "info", "Log level"); err != nil {
+				t.Fatal(err)
+			}
+			if err := set.SetNormalizer(NormalizeName); err != nil {
+				t.Fatal(err)
+			}
+			if err := set.SetNormalizer(test.normalizer); err != nil {
+				t.Fatal(err)
+			}
+			err := set.Parse(test.args)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("Parse(%q) error %v, expected %q", test.args, err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", test.args, err)
+			}
+			if got := set.Flag("--LOG_LEVEL").Value(); got != test.level {
+				t.Errorf("Parse(%q) --log-level=%v, expected %s", test.args, got, test.level)
+			}
+		})
+	}
+} //TestNormalizer()
+
+func TestNormalizerConfig(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(filename, []byte(`{"Log_Level": "warn"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	set := NewSet("test", "Test set")
+	if err := set.SetNormalizer(NormalizeName); err != nil {
+		t.Fatal(err)
+	}
+	level, err := set.String("", "--log-level", "info", "Log level")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := set.Config("", "--config", "Config file"); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"--config=" + filename}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if level.Value() != "warn" {
+		t.Errorf("expected the config key Log_Level to set --log-level, got %v", level.Value())
+	}
+} //TestNormalizerConfig()
+
+func TestNormalizerCollisions(t *testing.T) {
+	must := func(_ *FlagDescription, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("cannot define flag: %v", err)
+		}
+	}
+
+	//flags that only collide once normalized
+	set := NewSet("test", "Test set")
+	must(set.String("", "--log-level", "", "Log level"))
+	must(set.String("", "--log_level", "", "Other log level"))
+	var duplicate *DuplicateFlagError
+	err := set.SetNormalizer(NormalizeName)
+	if !errors.As(err, &duplicate) || duplicate.Option != "--log_level" || duplicate.Flag.long != "--log-level" {
+		t.Fatalf("expected *DuplicateFlagError for --log_level, got %v", err)
+	}
+	if err.Error() != "Set.SetNormalizer() failed: Duplicate long option --log_level, same as --log-level" {
+		t.Errorf("got message %q", err.Error())
+	}
+	//the set is unchanged when the normalizer cannot be set
+	if err := set.Parse([]string{"--log_level=x"}); err != nil || set.Flag("--log-level").Specified() {
+		t.Errorf("expected --log_level to still be a separate flag, got %v", err)
+	}
+
+	//flags added after the normalizer
+	set = NewSet("test", "Test set")
+	if err := set.SetNormalizer(NormalizeName); err != nil {
+		t.Fatal(err)
+	}
+	must(set.String("", "--log-level", "", "Log level"))
+	if _, err := set.String("", "--Log.Level", "", "Other log level"); !errors.As(err, &duplicate) || duplicate.Option != "--Log.Level" {
+		t.Errorf("expected *DuplicateFlagError for --Log.Level, got %v", err)
+	}
+
+	//flags copied into a set with the normalizer
+	other := NewSet("other", "Other set")
+	must(other.String("", "--LOG_LEVEL", "", "Other log level"))
+	if err := set.AddSet(*other); !errors.As(err, &duplicate) || duplicate.Option != "--LOG_LEVEL" {
+		t.Errorf("expected *DuplicateFlagError for --LOG_LEVEL, got %v", err)
+	}
+
+	//short options collide only when the normalizer changes them
+	set = NewSet("test", "Test set")
+	must(set.Bool("-v", "", false, "Verbose"))
+	must(set.Bool("-V", "", false, "Version"))
+	if err := set.SetNormalizer(NormalizeName); err != nil {
+		t.Errorf("expected -v and -V to remain separate, got %v", err)
+	}
+	err = set.SetNormalizer(func(name string) string { return strings.ToLower(name) })
+	if !errors.As(err, &duplicate) || duplicate.Option != "-V" || err.Error() != "Set.SetNormalizer() failed: Duplicate short option -V, same as -v" {
+		t.Errorf("expected *DuplicateFlagError for -V, got %v", err)
+	}
+} //TestNormalizerCollisions()
@@ -0,0 +1,108 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//Int64 adds an int64 flag to the set
+func (set *Set) Int64(short, long string, init int64, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Int64", short, long, init, doc)
+} //Set.Int64()
+
+//Int32 adds an int32 flag to the set
+func (set *Set) Int32(short, long string, init int32, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Int32", short, long, init, doc)
+} //Set.Int32()
+
+//Int16 adds an int16 flag to the set
+func (set *Set) Int16(short, long string, init int16, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Int16", short, long, init, doc)
+} //Set.Int16()
+
+//Int8 adds an int8 flag to the set
+func (set *Set) Int8(short, long string, init int8, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Int8", short, long, init, doc)
+} //Set.Int8()
+
+//Uint adds a uint flag to the set
+func (set *Set) Uint(short, long string, init uint, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Uint", short, long, init, doc)
+} //Set.Uint()
+
+//Uint64 adds a uint64 flag to the set
+func (set *Set) Uint64(short, long string, init uint64, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Uint64", short, long, init, doc)
+} //Set.Uint64()
+
+//Uint32 adds a uint32 flag to the set
+func (set *Set) Uint32(short, long string, init uint32, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Uint32", short, long, init, doc)
+} //Set.Uint32()
+
+//Uint16 adds a uint16 flag to the set
+func (set *Set) Uint16(short, long string, init uint16, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Uint16", short, long, init, doc)
+} //Set.Uint16()
+
+//Uint8 adds a uint8 flag to the set
+func (set *Set) Uint8(short, long string, init uint8, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Uint8", short, long, init, doc)
+} //Set.Uint8()
+
+//Float64 adds a float64 flag to the set
+func (set *Set) Float64(short, long string, init float64, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Float64", short, long, init, doc)
+} //Set.Float64()
+
+//Float32 adds a float32 flag to the set
+func (set *Set) Float32(short, long string, init float32, doc string) (*FlagDescription, error) {
+	return addScalar(set, "Float32", short, long, init, doc)
+} //Set.Float32()
+
+//addScalar adds a flag of type T to the set for the Set method with the given name
+func addScalar[T Scalar](set *Set, method string, short, long string, init T, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.%s() called on set==nil", method)
+	}
+	//create the new flag
+	value := init
+	newFlag, err := newFlag(short, long, newScalar(&value), nil, doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.%s() cannot add %s %s: %w", method, short, long, err)
+	}
+	//add
+	newFlagPtr, err := set.Add(newFlag)
+	if err != nil {
+		return nil, fmt.Errorf("Set.%s() cannot add %s %s: %w", method, short, long, err)
+	}
+	return newFlagPtr, nil
+} //addScalar()
+
+//parseInt parses a signed integer in decimal, or with prefix 0x (hex), 0o (octal)
+//or 0b (binary), and allows '_' between digits, e.g. "1_000" or "0xff_ff"
+func parseInt(s string, bitSize int) (int64, error) {
+	return strconv.ParseInt(decimalLeadingZeros(s), 0, bitSize)
+} //parseInt()
+
+//parseUint parses an unsigned integer with the same syntax as parseInt()
+func parseUint(s string, bitSize int) (uint64, error) {
+	return strconv.ParseUint(decimalLeadingZeros(s), 0, bitSize)
+} //parseUint()
+
+//decimalLeadingZeros removes leading zeros from decimal numbers, so that
+//"010" is 10 and not octal 8 as in Go syntax, leaving 0o as the octal prefix
+func decimalLeadingZeros(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+	if len(s) > 1 && s[0] == '0' && (s[1] == '_' || (s[1] >= '0' && s[1] <= '9')) {
+		s = strings.TrimLeft(s, "0_")
+		if s == "" {
+			s = "0"
+		}
+	}
+	return sign + s
+} //decimalLeadingZeros()
@@ -0,0 +1,62 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNumeric(t *testing.T) {
+	tests := []struct {
+		add      func(set *Set) (*FlagDescription, error)
+		value    string
+		expected string
+		err      string
+	}{
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int("-n", "", 0, "Number") }, value: "0x1f", expected: "31"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int("-n", "", 0, "Number") }, value: "0o17", expected: "15"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int("-n", "", 0, "Number") }, value: "0b101", expected: "5"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int("-n", "", 0, "Number") }, value: "1_000", expected: "1000"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int("-n", "", 0, "Number") }, value: "010", expected: "10"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int("-n", "", 0, "Number") }, value: "-0x10", expected: "-16"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int("-n", "", 0, "Number") }, value: "00", expected: "0"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int("-n", "", 0, "Number") }, value: "1.5", err: "syntax"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int8("-n", "", 0, "Number") }, value: "-128", expected: "-128"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int8("-n", "", 0, "Number") }, value: "128", err: `-n value "128" is out of range for <int8>`},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int16("-n", "", 0, "Number") }, value: "0x8000", err: "out of range for <int16>"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int32("-n", "", 0, "Number") }, value: "-2147483648", expected: "-2147483648"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Int64("-n", "", 0, "Number") }, value: "9223372036854775808", err: "out of range for <int64>"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Uint8("-n", "", 0, "Number") }, value: "0xff", expected: "255"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Uint8("-n", "", 0, "Number") }, value: "256", err: "out of range for <uint8>"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Uint16("-n", "", 0, "Number") }, value: "-1", err: "syntax"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Uint32("-n", "", 0, "Number") }, value: "0b1_0000", expected: "16"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Uint64("-n", "", 0, "Number") }, value: "18446744073709551615", expected: "18446744073709551615"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Uint("-n", "", 0, "Number") }, value: "0o777", expected: "511"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Float64("-n", "", 0, "Number") }, value: "1.5e3", expected: "1500"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Float32("-n", "", 0, "Number") }, value: "1e39", err: "out of range for <float32>"},
+		{add: func(set *Set) (*FlagDescription, error) { return set.Float64("-n", "", 0, "Number") }, value: "x", err: "syntax"},
+	}
+	for _, test := range tests {
+		set := NewSet("test", "Test set")
+		flag, err := test.add(set)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = set.Parse([]string{"-n", test.value})
+		if test.err != "" {
+			var invalid *InvalidValueError
+			if !errors.As(err, &invalid) || invalid.Value != test.value || !strings.Contains(err.Error()+" "+invalid.Err.Error(), test.err) {
+				t.Errorf("<%s> %s: expected invalid value error %q, got %v", flag.typeName(), test.value, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("<%s> %s failed: %v", flag.typeName(), test.value, err)
+			continue
+		}
+		if got := fmt.Sprintf("%v", flag.Value()); got != test.expected {
+			t.Errorf("<%s> %s = %s, expected %s", flag.typeName(), test.value, got, test.expected)
+		}
+	}
+} //TestNumeric()
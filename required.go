@@ -0,0 +1,79 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+)
+
+//requiredIf is an option of a group flag that makes a flag required when selected
+type requiredIf struct {
+	group  *FlagDescription
+	option string
+}
+
+//Required marks the flag as required, so parsing fails when it is not
+//specified on the command line, in the environment or in a config file
+func (f *FlagDescription) Required() *FlagDescription {
+	f.required = true
+	return f
+} //FlagDescription.Required()
+
+//RequiredIf marks the flag as required only when the option is selected
+//in the group flag, e.g. input.RequiredIf(operFlag, "import")
+//Call it again for each option that requires the flag.
+func (f *FlagDescription) RequiredIf(groupFlag *FlagDescription, option string) *FlagDescription {
+	if groupFlag != nil {
+		f.requiredIf = append(f.requiredIf, requiredIf{group: groupFlag, option: option})
+	}
+	return f
+} //FlagDescription.RequiredIf()
+
+//isRequired is true when the flag must be specified with the options selected so far
+func (f FlagDescription) isRequired() bool {
+	if f.required {
+		return true
+	}
+	for _, r := range f.requiredIf {
+		if r.group.value != nil && r.group.value.String() == r.option {
+			return true
+		}
+	}
+	return false
+} //FlagDescription.isRequired()
+
+//requiredUsage describes when the flag is required in usage, or "" if optional
+func (f FlagDescription) requiredUsage() string {
+	if f.required {
+		return " (required)"
+	}
+	if len(f.requiredIf) == 0 {
+		return ""
+	}
+	with := make([]string, 0, len(f.requiredIf))
+	for _, r := range f.requiredIf {
+		with = append(with, fmt.Sprintf("%n %s", r.group, r.option))
+	}
+	return " (required with " + strings.Join(with, " or ") + ")"
+} //FlagDescription.requiredUsage()
+
+//missingRequired lists the required flags not specified in the set and its selected groups
+func (set Set) missingRequired() []string {
+	missing := make([]string, 0)
+	for _, flag := range set.flags {
+		if flag.isRequired() && !flag.Specified() {
+			missing = append(missing, fmt.Sprintf("%N", flag))
+		}
+		if selected := flag.Selected(); selected != nil {
+			missing = append(missing, selected.missingRequired()...)
+		}
+	}
+	return missing
+} //Set.missingRequired()
+
+//checkRequired fails with all missing required flags in one error
+func (set Set) checkRequired() error {
+	if missing := set.missingRequired(); len(missing) > 0 {
+		return fmt.Errorf("Missing required options: %s", strings.Join(missing, ", "))
+	}
+	return nil
+} //Set.checkRequired()
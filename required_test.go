@@ -0,0 +1,82 @@
+package flags
+
+import (
+	"testing"
+)
+
+//newRequiredSet makes a set with a required flag and a flag required with -o add
+func newRequiredSet(t *testing.T) *Set {
+	t.Helper()
+	set := newTestSet(t)
+	name, err := set.String("-n", "--name", "", "Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name.Required()
+	input, err := set.String("-i", "--input", "", "Input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oper := set.long["--oper"]
+	input.RequiredIf(oper, "add").RequiredIf(oper, "mod")
+	oper.group["del"].set.long["--force"].Required()
+	return set
+} //newRequiredSet()
+
+func TestRequired(t *testing.T) {
+	t.Setenv("TEST_NAME", "env")
+	tests := []struct {
+		name string
+		copy bool
+		env  bool
+		args []string
+		err  string
+	}{
+		{name: "required", args: []string{"-n", "joe"}},
+		{name: "missing", args: []string{}, err: "Missing required options: -n (--name)"},
+		{name: "from the environment", env: true, args: []string{}},
+		{name: "required with group option", args: []string{"-n", "joe", "-o", "add"}, err: "Missing required options: -i (--input)"},
+		{name: "all missing in one error", args: []string{"-o", "add"}, err: "Missing required options: -n (--name), -i (--input)"},
+		{name: "required with group option specified", args: []string{"-n", "joe", "-o", "add", "-i", "x"}},
+		{name: "required in selected group option", args: []string{"-n", "joe", "-o", "del"}, err: "Missing required options: -f (--force)"},
+		{name: "copied required", copy: true, args: []string{}, err: "Missing required options: -n (--name)"},
+		{name: "copied required with group option", copy: true, args: []string{"-n", "joe", "-o", "add"}, err: "Missing required options: -i (--input)"},
+		{name: "copied required with group option specified", copy: true, args: []string{"-n", "joe", "-o", "add", "-i", "x"}},
+		{name: "copied not required with other group option", copy: true, args: []string{"-n", "joe", "-o", "del", "-f"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := newRequiredSet(t)
+			if test.copy {
+				copied := NewSet("copy", "Copied set")
+				if err := copied.AddSet(*set); err != nil {
+					t.Fatal(err)
+				}
+				set = copied
+			}
+			if test.env {
+				set.SetEnvPrefix("TEST")
+			}
+			err := set.Parse(test.args)
+			if test.err == "" && err != nil {
+				t.Fatalf("Parse(%q) failed: %v", test.args, err)
+			}
+			if test.err != "" && (err == nil || err.Error() != test.err) {
+				t.Fatalf("Parse(%q) error %v, expected %q", test.args, err, test.err)
+			}
+		})
+	}
+} //TestRequired()
+
+func TestRequiredUsage(t *testing.T) {
+	set := newRequiredSet(t)
+	if got := set.long["--name"].requiredUsage(); got != " (required)" {
+		t.Errorf("--name usage %q", got)
+	}
+	if got := set.long["--input"].requiredUsage(); got != " (required with -o add or -o mod)" {
+		t.Errorf("--input usage %q", got)
+	}
+	if got := set.long["--limit"].requiredUsage(); got != "" {
+		t.Errorf("--limit usage %q", got)
+	}
+} //TestRequiredUsage()
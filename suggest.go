@@ -0,0 +1,115 @@
+package flags
+
+import (
+	"sort"
+	"strings"
+)
+
+//suggest returns the candidates closest to s when they are close enough
+//to be what was meant, e.g. "limit" for "limt"
+func suggest(s string, candidates []string) []string {
+	//allow one typo per 3 characters, but never all of s
+	maxDistance := (len(s) + 2) / 3
+	if maxDistance >= len(s) {
+		maxDistance = len(s) - 1
+	}
+	best := make([]string, 0)
+	bestDistance := maxDistance + 1
+	for _, c := range candidates {
+		d := editDistance(s, c)
+		if d > maxDistance {
+			continue
+		}
+		if d < bestDistance {
+			best = []string{c}
+			bestDistance = d
+		} else if d == bestDistance {
+			best = append(best, c)
+		}
+	}
+	sort.Strings(best)
+	return best
+} //suggest()
+
+//editDistance is the Levenshtein distance between a and b,
+//i.e. the nr of characters to insert, delete or replace to turn a into b
+func editDistance(a, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+} //editDistance()
+
+//suggestOptions returns the options of the set and its selected groups
+//closest to the unknown option opt, e.g. "--limit" for "--limt=5"
+func (set Set) suggestOptions(opt string) []string {
+	options := make(map[string]string) //option name without dashes -> option
+	set.optionNames(options)
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	name := strings.TrimLeft(strings.SplitN(opt, "=", 2)[0], "-")
+	suggestions := suggest(name, names)
+	for i, s := range suggestions {
+		suggestions[i] = options[s]
+	}
+	return suggestions
+} //Set.suggestOptions()
+
+//optionNames adds the options of the set and its selected groups, keyed by name without dashes
+func (set Set) optionNames(options map[string]string) {
+	for _, flag := range set.flags {
+		if flag.short != "" {
+			options[flag.short[1:]] = flag.short
+		}
+		if flag.long != "" {
+			options[flag.long[2:]] = flag.long
+		}
+		if selected := flag.Selected(); selected != nil {
+			selected.optionNames(options)
+		}
+	}
+} //Set.optionNames()
+
+//allowed returns the values allowed for Select and Group flags, or nil for other flags
+func (f FlagDescription) allowed() []string {
+	if f.group == nil {
+		return f.allow
+	}
+	names := make([]string, 0, len(f.group))
+	for name := range f.group {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+} //FlagDescription.allowed()
+
+//didYouMean formats suggestions to add to an error message, or "" if none
+func didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return ", did you mean " + joinNames(suggestions, "or") + "?"
+} //didYouMean()
@@ -0,0 +1,109 @@
+package flags
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"limit", "limit", 0},
+		{"limt", "limit", 1},
+		{"lmiit", "limit", 2},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"café", "cafe", 1},
+	}
+	for _, test := range tests {
+		if got := editDistance(test.a, test.b); got != test.expected {
+			t.Errorf("editDistance(%s, %s) = %d, expected %d", test.a, test.b, got, test.expected)
+		}
+		if got := editDistance(test.b, test.a); got != test.expected {
+			t.Errorf("editDistance(%s, %s) = %d, expected %d", test.b, test.a, got, test.expected)
+		}
+	}
+} //TestEditDistance()
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"limit", "list", "verbose", "version", "v"}
+	tests := []struct {
+		s        string
+		expected []string
+	}{
+		{"limt", []string{"limit", "list"}},
+		{"lisst", []string{"list"}},
+		{"verson", []string{"version"}},
+		{"vers", []string{}},
+		{"x", []string{}},
+		{"w", []string{}},
+		{"lmiit", []string{"limit", "list"}},
+		{"bogus", []string{}},
+	}
+	for _, test := range tests {
+		if got := suggest(test.s, candidates); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("suggest(%s) = %q, expected %q", test.s, got, test.expected)
+		}
+	}
+} //TestSuggest()
+
+func TestSuggestionMessages(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{
+			args:     []string{"--limt=5"},
+			expected: "Unknown options: [--limt=5], did you mean --limit?",
+		},
+		{
+			args:     []string{"--debgu", "--eror"},
+			expected: "Unknown options: [--debgu --eror], did you mean --debug or --error?",
+		},
+		{
+			args:     []string{"-o", "add", "--usr=joe"},
+			expected: "Unknown options: [--usr=joe], did you mean --user?",
+		},
+		{
+			args:     []string{"-o", "dell"},
+			expected: `, did you mean del?`,
+		},
+		{
+			args:     []string{"-c", "gren"},
+			expected: `-c value "gren" is not valid: -c --colour value "gren" must be one of [red green grey], did you mean green or grey?`,
+		},
+	}
+	for _, test := range tests {
+		err := newTestSet(t).Parse(test.args)
+		if err == nil || !strings.HasSuffix(err.Error(), test.expected) {
+			t.Errorf("Parse(%q) error %v, expected %q", test.args, err, test.expected)
+		}
+	}
+} //TestSuggestionMessages()
+
+func TestSuggestionErrors(t *testing.T) {
+	var unknown *UnknownFlagError
+	err := newTestSet(t).Parse([]string{"-d", "--limt=5"})
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected *UnknownFlagError, got %v", err)
+	}
+	if unknown.Arg != "--limt=5" || unknown.Index != 1 || !reflect.DeepEqual(unknown.Suggestions, []string{"--limit"}) {
+		t.Errorf("got %+v", *unknown)
+	}
+
+	var invalid *InvalidValueError
+	err = newTestSet(t).Parse([]string{"-c", "gren"})
+	if !errors.As(err, &invalid) || invalid.Index != 1 || !reflect.DeepEqual(invalid.Suggestions, []string{"green", "grey"}) {
+		t.Errorf("expected *InvalidValueError at 1 suggesting green or grey, got %v", err)
+	}
+
+	err = newTestSet(t).Parse([]string{"--bogus"})
+	if !errors.As(err, &unknown) || len(unknown.Suggestions) != 0 {
+		t.Errorf("expected *UnknownFlagError without suggestions, got %v", err)
+	}
+} //TestSuggestionErrors()
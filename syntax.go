@@ -0,0 +1,118 @@
+package flags
+
+import (
+	"sort"
+	"strings"
+)
+
+//Syntax selects command line syntax variations that a set accepts
+//in addition to "-l 5" and "--limit=5". By default none are enabled.
+type Syntax int
+
+const (
+	//ShortClusters allows bool short options to be combined, e.g. "-de" for "-d -e"
+	ShortClusters Syntax = 1 << iota
+	//ShortAttached allows a short option value without a space, e.g. "-l5" or "-ofile"
+	ShortAttached
+	//LongSeparate allows a long option value in the next argument, e.g. "--limit 5"
+	LongSeparate
+	//Abbreviations allows unique prefixes of long options, e.g. "--verb" for "--verbose",
+	//and of Select values and Group options, e.g. "-o ad" for "-o add"
+	Abbreviations
+
+	//GNU enables the syntax variations of getopt_long, except Abbreviations
+	//which must be added, e.g. set.SetSyntax(flags.GNU | flags.Abbreviations)
+	GNU = ShortClusters | ShortAttached | LongSeparate
+)
+
+//SetSyntax selects the syntax variations accepted by the set
+func (set *Set) SetSyntax(syntax Syntax) {
+	set.syntax = syntax
+} //Set.SetSyntax()
+
+//splitCluster splits "-abc" into the bool short options it consists of,
+//optionally followed by one short option taking the rest of opt as its value
+//e.g. "-dl5" is -d and -l with value "5"
+//Returns ok=false when the syntax is not enabled or any part is unknown, so
+//that nothing is applied for a partially valid cluster.
+//A repeated counter like "-vvv" is split with any syntax.
+func (set *Set) splitCluster(opt string) (boolFlags []*FlagDescription, flag *FlagDescription, value string, ok bool) {
+	if repeated := set.repeatedCounter(opt); repeated != nil {
+		return repeated, nil, "", true
+	}
+	if set.syntax&(ShortClusters|ShortAttached) == 0 || len(opt) < 3 || !strings.HasPrefix(opt, "-") || strings.HasPrefix(opt, "--") {
+		return nil, nil, "", false
+	}
+	boolFlags = make([]*FlagDescription, 0)
+	for j := 1; j < len(opt); j++ {
+		f, found := set.shortFlag("-" + opt[j:j+1])
+		if !found {
+			return nil, nil, "", false
+		}
+		if f.isBool() {
+			if set.syntax&ShortClusters == 0 {
+				return nil, nil, "", false
+			}
+			boolFlags = append(boolFlags, f)
+			continue
+		}
+		//option with a value: the rest is the value, or the next argument
+		value = opt[j+1:]
+		if value != "" && set.syntax&ShortAttached == 0 {
+			return nil, nil, "", false
+		}
+		return boolFlags, f, value, true
+	}
+	return boolFlags, nil, "", true
+} //Set.splitCluster()
+
+//matchLong finds the long option that word is a unique prefix of, e.g. "--verb" for "--verbose"
+//Returns nil when none match, and an *AmbiguousError when more than one match.
+func (set *Set) matchLong(word string) (*FlagDescription, error) {
+	if set.syntax&Abbreviations == 0 || !strings.HasPrefix(word, "--") || len(word) < 3 {
+		return nil, nil
+	}
+	prefix := set.normalize(word)
+	candidates := make([]string, 0)
+	for long := range set.long {
+		if strings.HasPrefix(long, prefix) {
+			candidates = append(candidates, long)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		return set.long[candidates[0]], nil
+	}
+	for i, long := range candidates {
+		candidates[i] = set.long[long].long
+	}
+	sort.Strings(candidates)
+	return nil, &AmbiguousError{Arg: word, Index: -1, Candidates: candidates}
+} //Set.matchLong()
+
+//expandValue returns the Select value or Group option that s is a unique prefix of
+//when the set allows abbreviations, else s
+func (set *Set) expandValue(flag *FlagDescription, s string) (string, error) {
+	allowed := flag.allowed()
+	if set.syntax&Abbreviations == 0 || len(allowed) == 0 || s == "" {
+		return s, nil
+	}
+	candidates := make([]string, 0)
+	for _, a := range allowed {
+		if a == s {
+			return s, nil
+		}
+		if strings.HasPrefix(a, s) {
+			candidates = append(candidates, a)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return s, nil
+	case 1:
+		return candidates[0], nil
+	}
+	return s, &AmbiguousError{Flag: flag, Arg: s, Index: -1, Candidates: candidates}
+} //Set.expandValue()
@@ -0,0 +1,164 @@
+package flags
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSyntax(t *testing.T) {
+	runParseTests(t, []parseTest{
+		{
+			name:      "clusters need GNU syntax",
+			args:      []string{"-de"},
+			remaining: []string{"-de"},
+			values:    map[string]string{"-d": "false", "-e": "false"},
+		},
+		{
+			name:      "GNU clusters",
+			syntax:    GNU,
+			args:      []string{"-de"},
+			remaining: []string{},
+			values:    map[string]string{"-d": "true", "-e": "true"},
+		},
+		{
+			name:      "GNU cluster with attached value",
+			syntax:    GNU,
+			args:      []string{"-dl5"},
+			remaining: []string{},
+			values:    map[string]string{"-d": "true", "-l": "5"},
+		},
+		{
+			name:      "GNU cluster with value in next argument",
+			syntax:    GNU,
+			args:      []string{"-dl", "5"},
+			remaining: []string{},
+			values:    map[string]string{"-d": "true", "-l": "5"},
+		},
+		{
+			name:      "GNU cluster with unknown option is not applied",
+			syntax:    GNU,
+			args:      []string{"-dx"},
+			remaining: []string{"-dx"},
+			values:    map[string]string{"-d": "false"},
+		},
+		{
+			name:      "GNU long option with separate value",
+			syntax:    GNU,
+			args:      []string{"--limit", "5"},
+			remaining: []string{},
+			values:    map[string]string{"-l": "5"},
+		},
+		{
+			name:      "attached value without clusters",
+			syntax:    ShortAttached,
+			args:      []string{"-l5", "-de"},
+			remaining: []string{"-de"},
+			values:    map[string]string{"-l": "5", "-d": "false"},
+		},
+		{
+			name:      "clusters without attached values",
+			syntax:    ShortClusters,
+			args:      []string{"-de", "-dl5"},
+			remaining: []string{"-dl5"},
+			values:    map[string]string{"-d": "true", "-e": "true", "-l": "2"},
+		},
+		{
+			name:   "long option value needs LongSeparate",
+			syntax: ShortClusters | ShortAttached,
+			args:   []string{"--limit", "5"},
+			err:    "--limit=<integer>",
+		},
+	})
+} //TestSyntax()
+
+func TestAbbreviations(t *testing.T) {
+	runParseTests(t, []parseTest{
+		{
+			name:      "abbreviations",
+			syntax:    Abbreviations,
+			args:      []string{"--deb", "--col=gree"},
+			remaining: []string{},
+			values:    map[string]string{"-d": "true", "-c": "green"},
+		},
+		{
+			name:   "ambiguous abbreviation",
+			syntax: Abbreviations,
+			args:   []string{"--col=gre"},
+			err:    `Ambiguous -c value "gre", could be green or grey`,
+		},
+		{
+			name:      "abbreviated group option",
+			syntax:    Abbreviations,
+			args:      []string{"-o", "de", "-f"},
+			remaining: []string{},
+			values:    map[string]string{"-o": "del", "-f": "true"},
+		},
+		{
+			name:      "abbreviations need the Abbreviations syntax",
+			args:      []string{"--deb", "--col=gree"},
+			remaining: []string{"--deb", "--col=gree"},
+			values:    map[string]string{"-d": "false", "-c": "red"},
+		},
+		{
+			name:      "abbreviations with GNU syntax",
+			syntax:    GNU | Abbreviations,
+			args:      []string{"--lim", "5", "--verb"},
+			remaining: []string{},
+			values:    map[string]string{"-l": "5", "-v": "1"},
+		},
+		{
+			name:   "ambiguous option",
+			syntax: Abbreviations,
+			args:   []string{"--d"},
+			err:    "Ambiguous option --d, could be --debug or --dry-run",
+		},
+		{
+			name:      "unique prefix of options with a common prefix",
+			syntax:    Abbreviations,
+			args:      []string{"--dr"},
+			remaining: []string{},
+			values:    map[string]string{"-d": "false", "--dry-run": "true"},
+		},
+	})
+} //TestAbbreviations()
+
+func TestAbbreviationExactMatch(t *testing.T) {
+	set := NewSet("test", "Test set")
+	set.SetSyntax(Abbreviations)
+	verb, err := set.Bool("", "--verb", false, "Verb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verbose, err := set.Bool("", "--verbose", false, "Verbose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	level, err := set.Select("", "--level", "info", []string{"info", "infos"}, "Level")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"--verb", "--level=info"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if !verb.Specified() || verbose.Specified() || level.Value() != "info" {
+		t.Errorf("expected the exact matches --verb and info, got --verb=%v --verbose=%v --level=%v", verb.Value(), verbose.Value(), level.Value())
+	}
+	if err := set.Parse([]string{"--verbo", "--level=infos"}); err != nil || !verbose.Specified() || level.Value() != "infos" {
+		t.Errorf("expected --verbose and infos, got %v", err)
+	}
+} //TestAbbreviationExactMatch()
+
+func TestAmbiguousError(t *testing.T) {
+	set := newTestSet(t)
+	set.SetSyntax(Abbreviations)
+	var ambiguous *AmbiguousError
+	err := set.Parse([]string{"-e", "--d"})
+	if !errors.As(err, &ambiguous) || ambiguous.Index != 1 || !reflect.DeepEqual(ambiguous.Candidates, []string{"--debug", "--dry-run"}) {
+		t.Errorf("expected *AmbiguousError at 1, got %v", err)
+	}
+	err = set.Parse([]string{"-d", "-c", "gr"})
+	if !errors.As(err, &ambiguous) || ambiguous.Index != 2 || !reflect.DeepEqual(ambiguous.Candidates, []string{"green", "grey"}) {
+		t.Errorf("expected *AmbiguousError at 2, got %v", err)
+	}
+} //TestAmbiguousError()
@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package flags
+
+import "os"
+
+//terminalWidth is 0 where the terminal size is not known, to use the default width
+func terminalWidth(file *os.File) int {
+	return 0
+} //terminalWidth()
@@ -0,0 +1,21 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package flags
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+//terminalWidth is the nr of columns of the terminal that file is, or 0 if not a terminal
+func terminalWidth(file *os.File) int {
+	var size struct {
+		rows, cols, x, y uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0
+	}
+	return int(size.cols)
+} //terminalWidth()
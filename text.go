@@ -0,0 +1,76 @@
+package flags
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//Text adds a flag for a type that implements encoding.TextUnmarshaler,
+//e.g. set.Text("", "--ip", &ip, "Listen address") with ip of type net.IP
+//Values are parsed with UnmarshalText() into ptr, and when the type also
+//implements encoding.TextMarshaler, MarshalText() formats the value in usage.
+//The current value of *ptr is the default, and ptr is shared by copies of the
+//flag, so parsing a set that the flag was copied to with Set.AddSet() sets it too.
+func (set *Set) Text(short, long string, ptr encoding.TextUnmarshaler, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.Text() called on set==nil")
+	}
+	if ptr == nil || reflect.ValueOf(ptr).Kind() != reflect.Ptr || reflect.ValueOf(ptr).IsNil() {
+		return nil, fmt.Errorf("Set.Text() cannot add %s %s: need a pointer, not %T", short, long, ptr)
+	}
+	//create the new flag
+	newFlag, err := newFlag(short, long, &textValue{p: ptr}, nil, doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Text() cannot add %s %s: %w", short, long, err)
+	}
+	//add
+	newFlagPtr, err := set.Add(newFlag)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Text() cannot add %s %s: %w", short, long, err)
+	}
+	return newFlagPtr, nil
+} //Set.Text()
+
+//textValue holds a value that is parsed with UnmarshalText()
+type textValue struct {
+	p encoding.TextUnmarshaler
+}
+
+func (v *textValue) Set(s string) error {
+	return v.p.UnmarshalText([]byte(s))
+}
+
+//String formats the value with MarshalText() if implemented
+func (v *textValue) String() string {
+	if m, ok := v.p.(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return ""
+		}
+		return string(text)
+	}
+	return fmt.Sprintf("%v", reflect.ValueOf(v.p).Elem().Interface())
+} //textValue.String()
+
+//Get returns the value that the pointer points to, e.g. a net.IP
+func (v *textValue) Get() interface{} {
+	return reflect.ValueOf(v.p).Elem().Interface()
+}
+
+//TypeName describes the type in usage, e.g. "ip" for net.IP
+func (v *textValue) TypeName() string {
+	if name := reflect.TypeOf(v.p).Elem().Name(); name != "" {
+		return strings.ToLower(name)
+	}
+	return "text"
+}
+
+func (v *textValue) save() interface{} {
+	return reflect.ValueOf(v.p).Elem().Interface()
+}
+
+func (v *textValue) restore(saved interface{}) {
+	reflect.ValueOf(v.p).Elem().Set(reflect.ValueOf(saved))
+}
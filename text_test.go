@@ -0,0 +1,61 @@
+package flags
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestSetText(t *testing.T) {
+	ip := net.ParseIP("127.0.0.1")
+	var origin point
+	set := NewSet("test", "Test set")
+	ipFlag, err := set.Text("", "--ip", &ip, "Address")
+	if err != nil {
+		t.Fatal(err)
+	}
+	originFlag, err := set.Text("-o", "--origin", &origin, "Origin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := set.Text("", "--nil", nil, "Nothing"); err == nil {
+		t.Errorf("expected Text() without a pointer to fail")
+	}
+	//net.IP formats itself with MarshalText(), and point is formatted with %v
+	if ipFlag.defaultValue != "127.0.0.1" || ipFlag.typeName() != "ip" || originFlag.defaultValue != "{0 0}" || originFlag.typeName() != "point" {
+		t.Errorf("got default %s <%s> and %s <%s>", ipFlag.defaultValue, ipFlag.typeName(), originFlag.defaultValue, originFlag.typeName())
+	}
+
+	if err := set.Parse([]string{"--ip=::1", "-o", "1,2"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if !ip.Equal(net.ParseIP("::1")) || origin != (point{1, 2}) {
+		t.Errorf("got ip %v and origin %v", ip, origin)
+	}
+	if v, ok := ipFlag.Value().(net.IP); !ok || !v.Equal(ip) {
+		t.Errorf("got value %v", ipFlag.Value())
+	}
+
+	var invalid *InvalidValueError
+	if err := set.Parse([]string{"--ip=localhost"}); !errors.As(err, &invalid) || invalid.Value != "localhost" {
+		t.Errorf("expected invalid ip, got %v", err)
+	}
+} //TestSetText()
+
+func TestSetTextCopy(t *testing.T) {
+	var ip net.IP
+	other := NewSet("other", "Other set")
+	if _, err := other.Text("", "--ip", &ip, "Address"); err != nil {
+		t.Fatal(err)
+	}
+	set := NewSet("test", "Test set")
+	if err := set.AddSet(*other); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"--ip=10.0.0.1"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if !ip.Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("parsing the copied flag did not set the caller's ip, got %v", ip)
+	}
+} //TestSetTextCopy()
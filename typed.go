@@ -0,0 +1,78 @@
+package flags
+
+import (
+	"fmt"
+)
+
+//TypedFlag is a typed handle to a flag in a set, to get the value without type assertions
+//(named so because Flag() already gets a flag description by name)
+type TypedFlag[T Scalar] struct {
+	*FlagDescription
+	p *T
+}
+
+//Get the parsed value of the flag
+func (f *TypedFlag[T]) Get() T {
+	return *f.p
+} //TypedFlag.Get()
+
+//Var adds a flag of type T to the set and returns a typed handle to it
+//e.g. limit, err := flags.Var(set, "-l", "--limit", 2, "Limit nr of records")
+//then after parsing, limit.Get() is the int value
+func Var[T Scalar](set *Set, short, long string, init T, doc string) (*TypedFlag[T], error) {
+	if set == nil {
+		return nil, fmt.Errorf("Var() called on set==nil")
+	}
+	//create the new flag
+	value := init
+	newFlag, err := newFlag(short, long, newScalar(&value), nil, doc)
+	if err != nil {
+		return nil, fmt.Errorf("Var() cannot add %s %s: %w", short, long, err)
+	}
+	//add
+	newFlagPtr, err := set.Add(newFlag)
+	if err != nil {
+		return nil, fmt.Errorf("Var() cannot add %s %s: %w", short, long, err)
+	}
+	return &TypedFlag[T]{FlagDescription: newFlagPtr, p: &value}, nil
+} //Var()
+
+//LookupError is returned by Lookup() when the flag does not exist or
+//is of another type
+type LookupError struct {
+	//Name used in the lookup
+	Name string
+	//Type that was requested
+	Type string
+	//Flag that was found, or nil if the name is unknown
+	Flag *FlagDescription
+}
+
+func (e *LookupError) Error() string {
+	if e.Flag == nil {
+		return fmt.Sprintf("Unknown flag %s", e.Name)
+	}
+	return fmt.Sprintf("Flag %s is <%s>, not <%s>", e.Name, e.Flag.typeName(), e.Type)
+} //LookupError.Error()
+
+//Lookup gets the value of a flag by short/long option or argument name,
+//returning a *LookupError instead of panicking on a wrong type or name
+//Besides flags of type T, it gets values of any flag whose Getter returns a T.
+func Lookup[T Scalar](set *Set, name string) (T, error) {
+	var zero T
+	if set == nil {
+		return zero, fmt.Errorf("Lookup() called on set==nil")
+	}
+	flag := set.Flag(name)
+	if flag.value == nil {
+		return zero, &LookupError{Name: name, Type: typeName[T]()}
+	}
+	if v, ok := flag.value.(*scalar[T]); ok {
+		return *v.p, nil
+	}
+	//other values with a Getter, e.g. the int of a Counter or the value of Set.Var()
+	if v, ok := flag.get().(T); ok {
+		return v, nil
+	}
+	return zero, &LookupError{Name: name, Type: typeName[T](), Flag: &flag}
+} //Lookup()
@@ -0,0 +1,67 @@
+package flags
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVar(t *testing.T) {
+	set := NewSet("test", "Test set")
+	limit, err := Var(set, "-l", "--limit", 2, "Limit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := Var(set, "-n", "--name", "joe", "Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ratio, err := Var(set, "-r", "--ratio", 0.5, "Ratio")
+	if err != nil {
+		t.Fatal(err)
+	}
+	debug, err := Var(set, "-d", "--debug", false, "Debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limit.Get() != 2 || name.Get() != "joe" || ratio.Get() != 0.5 || debug.Get() {
+		t.Errorf("got defaults %v %v %v %v", limit.Get(), name.Get(), ratio.Get(), debug.Get())
+	}
+	if err := set.Parse([]string{"-l", "7", "--name=ann", "-r", "1.25", "-d"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if limit.Get() != 7 || name.Get() != "ann" || ratio.Get() != 1.25 || !debug.Get() {
+		t.Errorf("got %v %v %v %v", limit.Get(), name.Get(), ratio.Get(), debug.Get())
+	}
+	if !limit.Specified() || limit.Value() != 7 {
+		t.Errorf("handle does not describe the flag: %v", limit.Value())
+	}
+	if _, err := Var(set, "-l", "--other", 1, "Duplicate"); err == nil {
+		t.Errorf("expected duplicate -l to fail")
+	}
+} //TestVar()
+
+func TestLookup(t *testing.T) {
+	set := newTestSet(t)
+	if err := set.Parse([]string{"-l", "5", "-v", "-v", "-o", "add", "-u", "joe"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if limit, err := Lookup[int](set, "--limit"); err != nil || limit != 5 {
+		t.Errorf("Lookup[int](--limit) = %v, %v", limit, err)
+	}
+	if verbose, err := Lookup[int](set, "-v"); err != nil || verbose != 2 {
+		t.Errorf("Lookup[int](-v) = %v, %v", verbose, err)
+	}
+	if oper, err := Lookup[string](set, "-o"); err != nil || oper != "add" {
+		t.Errorf("Lookup[string](-o) = %v, %v", oper, err)
+	}
+
+	var lookupErr *LookupError
+	_, err := Lookup[string](set, "-l")
+	if !errors.As(err, &lookupErr) || lookupErr.Flag == nil || err.Error() != "Flag -l is <integer>, not <string>" {
+		t.Errorf("Lookup[string](-l) expected wrong type, got %v", err)
+	}
+	_, err = Lookup[int](set, "--bogus")
+	if !errors.As(err, &lookupErr) || lookupErr.Flag != nil || err.Error() != "Unknown flag --bogus" {
+		t.Errorf("Lookup[int](--bogus) expected unknown flag, got %v", err)
+	}
+} //TestLookup()
@@ -0,0 +1,388 @@
+package flags
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+//Validator validates flag values and describes the rule in usage,
+//e.g. flag.Validate(flags.Range(1, 100)) is shown as "(1..100)"
+//Validators get each value of any kind of flag, and each element of a list.
+type Validator interface {
+	//Validate returns an error when the value is not valid
+	Validate(value interface{}) error
+	//Describe the rule in usage, or "" for none
+	Describe() string
+}
+
+//Validate calls the function, so it can be used as a Validator
+func (fn FlagValueValidationFunc) Validate(value interface{}) error {
+	return fn(value)
+}
+
+//Describe returns "" as functions are not described in usage
+func (fn FlagValueValidationFunc) Describe() string {
+	return ""
+}
+
+//Validate adds validators that every value of the flag must pass
+func (f *FlagDescription) Validate(validators ...Validator) *FlagDescription {
+	for _, v := range validators {
+		if v != nil {
+			f.validators = append(f.validators, v)
+		}
+	}
+	return f
+} //FlagDescription.Validate()
+
+//validatorUsage describes the validators in usage, or "" if none are described
+func (f FlagDescription) validatorUsage() string {
+	descriptions := make([]string, 0, len(f.validators))
+	for _, v := range f.validators {
+		if d := v.Describe(); d != "" {
+			descriptions = append(descriptions, d)
+		}
+	}
+	if len(descriptions) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(descriptions, ", ") + ")"
+} //FlagDescription.validatorUsage()
+
+//rule is a described validation function
+type rule struct {
+	description string
+	check       func(value interface{}) error
+}
+
+func (r rule) Validate(value interface{}) error {
+	return r.check(value)
+}
+
+func (r rule) Describe() string {
+	return r.description
+}
+
+//Min fails on numbers less than min, e.g. Min(1) or Min(uint64(1<<63))
+//Numbers are compared exactly, also int64 and uint64 values beyond the precision of float64.
+func Min[T Number](min T) Validator {
+	return rule{
+		description: fmt.Sprintf(">=%v", min),
+		check: func(value interface{}) error {
+			c, err := compareNumber(value, min)
+			if err != nil {
+				return err
+			}
+			if c < 0 {
+				return fmt.Errorf("must be >=%v", min)
+			}
+			return nil
+		},
+	}
+} //Min()
+
+//Max fails on numbers more than max, e.g. Max(100) or Max(math.MaxInt64)
+func Max[T Number](max T) Validator {
+	return rule{
+		description: fmt.Sprintf("<=%v", max),
+		check: func(value interface{}) error {
+			c, err := compareNumber(value, max)
+			if err != nil {
+				return err
+			}
+			if c > 0 {
+				return fmt.Errorf("must be <=%v", max)
+			}
+			return nil
+		},
+	}
+} //Max()
+
+//Range fails on numbers outside min..max, e.g. Range(1, 100) or Range(0.5, 1.5)
+func Range[T Number](min, max T) Validator {
+	return rule{
+		description: fmt.Sprintf("%v..%v", min, max),
+		check: func(value interface{}) error {
+			cMin, err := compareNumber(value, min)
+			if err != nil {
+				return err
+			}
+			cMax, err := compareNumber(value, max)
+			if err != nil {
+				return err
+			}
+			if cMin < 0 || cMax > 0 {
+				return fmt.Errorf("must be %v..%v", min, max)
+			}
+			return nil
+		},
+	}
+} //Range()
+
+//Pattern fails on values that do not match the regular expression,
+//and panics when expr is not valid, like regexp.MustCompile()
+func Pattern(expr string) Validator {
+	re := regexp.MustCompile(expr)
+	return rule{
+		description: "matching " + expr,
+		check: func(value interface{}) error {
+			if !re.MatchString(toText(value)) {
+				return fmt.Errorf("must match %s", expr)
+			}
+			return nil
+		},
+	}
+} //Pattern()
+
+//MinLen fails on values shorter than min characters
+func MinLen(min int) Validator {
+	return rule{
+		description: fmt.Sprintf("min %d chars", min),
+		check: func(value interface{}) error {
+			if utf8.RuneCountInString(toText(value)) < min {
+				return fmt.Errorf("must be at least %d characters", min)
+			}
+			return nil
+		},
+	}
+} //MinLen()
+
+//MaxLen fails on values longer than max characters
+func MaxLen(max int) Validator {
+	return rule{
+		description: fmt.Sprintf("max %d chars", max),
+		check: func(value interface{}) error {
+			if utf8.RuneCountInString(toText(value)) > max {
+				return fmt.Errorf("must be at most %d characters", max)
+			}
+			return nil
+		},
+	}
+} //MaxLen()
+
+//OneOf fails on values that are not one of the allowed values
+func OneOf(allow ...string) Validator {
+	return rule{
+		description: "one of " + strings.Join(allow, "|"),
+		check: func(value interface{}) error {
+			s := toText(value)
+			for _, a := range allow {
+				if s == a {
+					return nil
+				}
+			}
+			return fmt.Errorf("must be one of %v", allow)
+		},
+	}
+} //OneOf()
+
+//FileExists fails on values that are not the name of an existing file
+func FileExists() Validator {
+	return rule{
+		description: "existing file",
+		check: func(value interface{}) error {
+			info, err := os.Stat(toText(value))
+			if err != nil {
+				return fmt.Errorf("file does not exist")
+			}
+			if info.IsDir() {
+				return fmt.Errorf("is a directory, not a file")
+			}
+			return nil
+		},
+	}
+} //FileExists()
+
+//DirExists fails on values that are not the name of an existing directory
+func DirExists() Validator {
+	return rule{
+		description: "existing directory",
+		check: func(value interface{}) error {
+			info, err := os.Stat(toText(value))
+			if err != nil {
+				return fmt.Errorf("directory does not exist")
+			}
+			if !info.IsDir() {
+				return fmt.Errorf("is not a directory")
+			}
+			return nil
+		},
+	}
+} //DirExists()
+
+//Writable fails on values that are not the name of a file that can be
+//written or created, or of a directory in which files can be created
+func Writable() Validator {
+	return rule{
+		description: "writable",
+		check: func(value interface{}) error {
+			name := toText(value)
+			dir := filepath.Dir(name)
+			if info, err := os.Stat(name); err == nil {
+				if !info.IsDir() {
+					file, err := os.OpenFile(name, os.O_WRONLY, 0)
+					if err != nil {
+						return fmt.Errorf("is not writable")
+					}
+					file.Close()
+					return nil
+				}
+				dir = name
+			}
+			file, err := os.CreateTemp(dir, ".writable-*")
+			if err != nil {
+				return fmt.Errorf("is not writable")
+			}
+			file.Close()
+			os.Remove(file.Name())
+			return nil
+		},
+	}
+} //Writable()
+
+//URL fails on values that are not absolute URLs with a scheme and host
+func URL() Validator {
+	return rule{
+		description: "URL",
+		check: func(value interface{}) error {
+			u, err := url.Parse(toText(value))
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("must be a URL like scheme://host/path")
+			}
+			return nil
+		},
+	}
+} //URL()
+
+//And fails when any of the validators fail
+func And(validators ...Validator) Validator {
+	return rule{
+		description: describeAll(validators, " and "),
+		check: func(value interface{}) error {
+			for _, v := range validators {
+				if err := v.Validate(value); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+} //And()
+
+//Or fails when all of the validators fail
+func Or(validators ...Validator) Validator {
+	return rule{
+		description: describeAll(validators, " or "),
+		check: func(value interface{}) error {
+			errs := make([]string, 0, len(validators))
+			for _, v := range validators {
+				err := v.Validate(value)
+				if err == nil {
+					return nil
+				}
+				errs = append(errs, err.Error())
+			}
+			return fmt.Errorf("%s", strings.Join(errs, " or "))
+		},
+	}
+} //Or()
+
+//Not fails when the validator passes
+func Not(validator Validator) Validator {
+	description := validator.Describe()
+	if description != "" {
+		description = "not " + description
+	}
+	return rule{
+		description: description,
+		check: func(value interface{}) error {
+			if validator.Validate(value) == nil {
+				if description == "" {
+					return fmt.Errorf("is not allowed")
+				}
+				return fmt.Errorf("must be %s", description)
+			}
+			return nil
+		},
+	}
+} //Not()
+
+//describeAll joins the descriptions of the validators
+func describeAll(validators []Validator, sep string) string {
+	descriptions := make([]string, 0, len(validators))
+	for _, v := range validators {
+		if d := v.Describe(); d != "" {
+			descriptions = append(descriptions, d)
+		}
+	}
+	return strings.Join(descriptions, sep)
+} //describeAll()
+
+//compareNumber compares a numeric value with a bound, returning -1, 0 or +1
+//when the value is less than, equal to or more than the bound
+func compareNumber(value interface{}, bound interface{}) (int, error) {
+	n, err := toNumber(value)
+	if err != nil {
+		return 0, err
+	}
+	b, err := toNumber(bound)
+	if err != nil {
+		return 0, err
+	}
+	return n.Cmp(b), nil
+} //compareNumber()
+
+//toNumber converts a numeric value to a big.Float to compare it exactly,
+//as int64 and uint64 values do not all fit in a float64
+func toNumber(value interface{}) (*big.Float, error) {
+	switch n := value.(type) {
+	case int:
+		return new(big.Float).SetInt64(int64(n)), nil
+	case int8:
+		return new(big.Float).SetInt64(int64(n)), nil
+	case int16:
+		return new(big.Float).SetInt64(int64(n)), nil
+	case int32:
+		return new(big.Float).SetInt64(int64(n)), nil
+	case int64:
+		return new(big.Float).SetInt64(n), nil
+	case uint:
+		return new(big.Float).SetUint64(uint64(n)), nil
+	case uint8:
+		return new(big.Float).SetUint64(uint64(n)), nil
+	case uint16:
+		return new(big.Float).SetUint64(uint64(n)), nil
+	case uint32:
+		return new(big.Float).SetUint64(uint64(n)), nil
+	case uint64:
+		return new(big.Float).SetUint64(n), nil
+	case float32:
+		return toFloat(float64(n))
+	case float64:
+		return toFloat(n)
+	}
+	return nil, fmt.Errorf("is not a number")
+} //toNumber()
+
+//toFloat converts a float64 to a big.Float, failing on NaN which cannot be compared
+func toFloat(f float64) (*big.Float, error) {
+	if math.IsNaN(f) {
+		return nil, fmt.Errorf("is not a number")
+	}
+	return new(big.Float).SetFloat64(f), nil
+} //toFloat()
+
+//toText formats a value as text to validate it as a string
+func toText(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+} //toText()
@@ -0,0 +1,120 @@
+package flags
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		validator Validator
+		value     interface{}
+		err       string
+	}{
+		{name: "min", validator: Min(1), value: 1},
+		{name: "below min", validator: Min(1), value: int8(0), err: "must be >=1"},
+		{name: "max", validator: Max(1.5), value: float32(1.5)},
+		{name: "above max", validator: Max(1.5), value: 1.75, err: "must be <=1.5"},
+		{name: "range", validator: Range(1, 100), value: uint(100)},
+		{name: "below range", validator: Range(1, 100), value: int64(0), err: "must be 1..100"},
+		{name: "above range", validator: Range(0.5, 1.5), value: 2, err: "must be 0.5..1.5"},
+		{name: "max int64", validator: Max(math.MaxInt64), value: int64(math.MaxInt64)},
+		{name: "above max int64", validator: Max(math.MaxInt64 - 1), value: int64(math.MaxInt64), err: "must be <=9223372036854775806"},
+		{name: "min uint64", validator: Min(uint64(math.MaxUint64)), value: uint64(math.MaxUint64 - 1), err: "must be >=18446744073709551615"},
+		{name: "range uint64", validator: Range(uint64(1<<63), uint64(1<<63+1)), value: uint64(1<<63 + 1)},
+		{name: "above range uint64", validator: Range(uint64(1<<63), uint64(1<<63+1)), value: uint64(1<<63 + 2), err: "must be 9223372036854775808..9223372036854775809"},
+		{name: "unsigned below negative", validator: Max(-1), value: uint(0), err: "must be <=-1"},
+		{name: "not a number", validator: Min(0), value: "1", err: "is not a number"},
+		{name: "NaN", validator: Min(0), value: math.NaN(), err: "is not a number"},
+		{name: "pattern", validator: Pattern("^[a-z]+$"), value: "abc"},
+		{name: "not matching pattern", validator: Pattern("^[a-z]+$"), value: "Abc", err: "must match ^[a-z]+$"},
+		{name: "pattern of a number", validator: Pattern("^[0-9]$"), value: 7},
+		{name: "min length", validator: MinLen(2), value: "é", err: "must be at least 2 characters"},
+		{name: "max length", validator: MaxLen(2), value: "éé"},
+		{name: "above max length", validator: MaxLen(2), value: "abc", err: "must be at most 2 characters"},
+		{name: "one of", validator: OneOf("a", "b"), value: "b"},
+		{name: "not one of", validator: OneOf("a", "b"), value: "c", err: "must be one of [a b]"},
+		{name: "file exists", validator: FileExists(), value: file},
+		{name: "file does not exist", validator: FileExists(), value: filepath.Join(dir, "missing"), err: "file does not exist"},
+		{name: "file is a directory", validator: FileExists(), value: dir, err: "is a directory, not a file"},
+		{name: "directory exists", validator: DirExists(), value: dir},
+		{name: "directory is a file", validator: DirExists(), value: file, err: "is not a directory"},
+		{name: "writable file", validator: Writable(), value: file},
+		{name: "writable new file", validator: Writable(), value: filepath.Join(dir, "new")},
+		{name: "writable directory", validator: Writable(), value: dir},
+		{name: "not writable", validator: Writable(), value: filepath.Join(dir, "missing", "new"), err: "is not writable"},
+		{name: "URL", validator: URL(), value: "https://example.com/x"},
+		{name: "not a URL", validator: URL(), value: "example.com/x", err: "must be a URL like scheme://host/path"},
+		{name: "and", validator: And(Min(1), Max(9)), value: 5},
+		{name: "and fails on first", validator: And(Min(1), Max(9)), value: 10, err: "must be <=9"},
+		{name: "or", validator: Or(Max(1), Min(9)), value: 10},
+		{name: "or fails on all", validator: Or(Max(1), Min(9)), value: 5, err: "must be <=1 or must be >=9"},
+		{name: "not", validator: Not(OneOf("root")), value: "joe"},
+		{name: "not fails", validator: Not(OneOf("root")), value: "root", err: "must be not one of root"},
+		{name: "function", validator: FlagValueValidationFunc(func(interface{}) error { return errors.New("never") }), value: 1, err: "never"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.validator.Validate(test.value)
+			if test.err == "" && err != nil {
+				t.Fatalf("Validate(%v) failed: %v", test.value, err)
+			}
+			if test.err != "" && (err == nil || err.Error() != test.err) {
+				t.Fatalf("Validate(%v) error %v, expected %q", test.value, err, test.err)
+			}
+		})
+	}
+} //TestValidators()
+
+func TestValidatorDescriptions(t *testing.T) {
+	tests := []struct {
+		validator Validator
+		expected  string
+	}{
+		{Min(1), ">=1"},
+		{Max(math.MaxInt64), "<=9223372036854775807"},
+		{Range(0.5, 1.5), "0.5..1.5"},
+		{Pattern("^x"), "matching ^x"},
+		{MinLen(2), "min 2 chars"},
+		{MaxLen(3), "max 3 chars"},
+		{OneOf("a", "b"), "one of a|b"},
+		{And(Min(1), FlagValueValidationFunc(func(interface{}) error { return nil }), Max(2)), ">=1 and <=2"},
+		{Or(OneOf("a"), URL()), "one of a or URL"},
+		{Not(FileExists()), "not existing file"},
+		{Not(FlagValueValidationFunc(func(interface{}) error { return nil })), ""},
+	}
+	for _, test := range tests {
+		if got := test.validator.Describe(); got != test.expected {
+			t.Errorf("Describe() = %q, expected %q", got, test.expected)
+		}
+	}
+} //TestValidatorDescriptions()
+
+func TestValidateFlag(t *testing.T) {
+	set := NewSet("test", "Test set")
+	ports, err := set.IntList("-p", "--port", nil, "Ports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ports.SetSeparator(",").Validate(Range(1, 65535), nil)
+	if got := ports.validatorUsage(); got != " (1..65535)" {
+		t.Errorf("usage %q", got)
+	}
+	if err := set.Parse([]string{"-p", "80,443"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	var invalid *InvalidValueError
+	err = set.Parse([]string{"-p", "80", "-p", "1,0"})
+	if !errors.As(err, &invalid) || invalid.Index != 3 || err.Error() != `-p value "1,0" is not valid: must be 1..65535` {
+		t.Errorf("expected element 0 to be invalid, got %v", err)
+	}
+} //TestValidateFlag()
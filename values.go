@@ -0,0 +1,371 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//Value holds the typed value of a flag and parses it from text
+//Implement it for flags of your own types and add them with Set.Var()
+//A value may also implement TypeName() string to describe its type in usage,
+//e.g. "semver", IsBool() bool to be true when the option is specified without
+//a value, and Getter to return its parsed value from FlagDescription.Value()
+type Value interface {
+	//Set parses s and stores it as the value
+	Set(s string) error
+	//String formats the value as text
+	String() string
+}
+
+//Getter is a Value that returns the parsed value, e.g. an int for an integer flag
+type Getter interface {
+	Value
+	Get() interface{}
+}
+
+//Var adds a flag with a value of your own type to the set,
+//e.g. set.Var("", "--version", &semver{}, "Minimum version")
+//where the current value of the Value is the default.
+func (set *Set) Var(short, long string, value Value, doc string) (*FlagDescription, error) {
+	if set == nil {
+		return nil, fmt.Errorf("Set.Var() called on set==nil")
+	}
+	if value == nil {
+		return nil, fmt.Errorf("Set.Var() cannot add %s %s: value==nil", short, long)
+	}
+	//create the new flag
+	newFlag, err := newFlag(short, long, value, nil, doc)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Var() cannot add %s %s: %w", short, long, err)
+	}
+	//add
+	newFlagPtr, err := set.Add(newFlag)
+	if err != nil {
+		return nil, fmt.Errorf("Set.Var() cannot add %s %s: %w", short, long, err)
+	}
+	return newFlagPtr, nil
+} //Set.Var()
+
+//cloner is implemented by values that can be copied, so that copied
+//flag descriptions (see Set.AddSet()) have their own values
+type cloner interface {
+	clone() Value
+}
+
+//restorer is implemented by values that can save a copy of what they hold and
+//restore it later, so that a flag can be restored to its default value
+type restorer interface {
+	save() interface{}
+	restore(saved interface{})
+}
+
+//Scalar are the types of single valued flags
+type Scalar interface {
+	bool | string | Number
+}
+
+//Number are the numeric types of flags
+type Number interface {
+	int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | float32 | float64
+}
+
+//scalar holds a single typed value
+type scalar[T Scalar] struct {
+	p *T
+}
+
+//newScalar makes a value that stores into *p
+func newScalar[T Scalar](p *T) *scalar[T] {
+	return &scalar[T]{p: p}
+} //newScalar()
+
+//Set parses s into the type of the value
+func (v *scalar[T]) Set(s string) error {
+	switch p := any(v.p).(type) {
+	case *bool:
+		if s == "true" {
+			*p = true
+		} else if s == "false" {
+			*p = false
+		} else {
+			return &strconv.NumError{Func: "ParseBool", Num: s, Err: strconv.ErrSyntax}
+		}
+	case *string:
+		*p = s
+	case *int:
+		i, err := parseInt(s, strconv.IntSize)
+		if err != nil {
+			return err
+		}
+		*p = int(i)
+	case *int8:
+		i, err := parseInt(s, 8)
+		if err != nil {
+			return err
+		}
+		*p = int8(i)
+	case *int16:
+		i, err := parseInt(s, 16)
+		if err != nil {
+			return err
+		}
+		*p = int16(i)
+	case *int32:
+		i, err := parseInt(s, 32)
+		if err != nil {
+			return err
+		}
+		*p = int32(i)
+	case *int64:
+		i, err := parseInt(s, 64)
+		if err != nil {
+			return err
+		}
+		*p = i
+	case *uint:
+		u, err := parseUint(s, strconv.IntSize)
+		if err != nil {
+			return err
+		}
+		*p = uint(u)
+	case *uint8:
+		u, err := parseUint(s, 8)
+		if err != nil {
+			return err
+		}
+		*p = uint8(u)
+	case *uint16:
+		u, err := parseUint(s, 16)
+		if err != nil {
+			return err
+		}
+		*p = uint16(u)
+	case *uint32:
+		u, err := parseUint(s, 32)
+		if err != nil {
+			return err
+		}
+		*p = uint32(u)
+	case *uint64:
+		u, err := parseUint(s, 64)
+		if err != nil {
+			return err
+		}
+		*p = u
+	case *float32:
+		f, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return err
+		}
+		*p = float32(f)
+	case *float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		*p = f
+	}
+	return nil
+} //scalar.Set()
+
+func (v *scalar[T]) String() string {
+	return fmt.Sprintf("%v", *v.p)
+}
+
+func (v *scalar[T]) Get() interface{} {
+	return *v.p
+}
+
+//TypeName describes the type in usage, e.g. "integer"
+func (v *scalar[T]) TypeName() string {
+	return typeName[T]()
+}
+
+//IsBool is true for values that do not need to be specified after the option
+func (v *scalar[T]) IsBool() bool {
+	_, ok := any(v.p).(*bool)
+	return ok
+}
+
+func (v *scalar[T]) clone() Value {
+	value := *v.p
+	return newScalar(&value)
+}
+
+func (v *scalar[T]) save() interface{} {
+	return *v.p
+}
+
+func (v *scalar[T]) restore(saved interface{}) {
+	*v.p = saved.(T)
+}
+
+//typeName describes type T in usage
+func typeName[T Scalar]() string {
+	var t T
+	switch any(t).(type) {
+	case bool:
+		return "bool"
+	case int:
+		return "integer"
+	case string:
+		return "string"
+	}
+	return fmt.Sprintf("%T", t)
+} //typeName()
+
+//newValue makes a value holding init, which is a bool, string or number,
+//or a []bool, []int or []string for a list
+func newValue(init interface{}) (Value, error) {
+	switch v := init.(type) {
+	case bool:
+		return newScalar(&v), nil
+	case int:
+		return newScalar(&v), nil
+	case string:
+		return newScalar(&v), nil
+	case int8:
+		return newScalar(&v), nil
+	case int16:
+		return newScalar(&v), nil
+	case int32:
+		return newScalar(&v), nil
+	case int64:
+		return newScalar(&v), nil
+	case uint:
+		return newScalar(&v), nil
+	case uint8:
+		return newScalar(&v), nil
+	case uint16:
+		return newScalar(&v), nil
+	case uint32:
+		return newScalar(&v), nil
+	case uint64:
+		return newScalar(&v), nil
+	case float32:
+		return newScalar(&v), nil
+	case float64:
+		return newScalar(&v), nil
+	case []bool:
+		return newList(&v), nil
+	case []int:
+		return newList(&v), nil
+	case []string:
+		return newList(&v), nil
+	}
+	return nil, fmt.Errorf("Values of type %T is not supported", init)
+} //newValue()
+
+//isBool is true when the flag does not need a value after the option
+func (f FlagDescription) isBool() bool {
+	if b, ok := f.value.(interface{ IsBool() bool }); ok {
+		return b.IsBool()
+	}
+	return false
+} //FlagDescription.isBool()
+
+//get returns the parsed value of the flag, which is the Value itself
+//when it does not implement Getter
+func (f FlagDescription) get() interface{} {
+	if g, ok := f.value.(Getter); ok {
+		return g.Get()
+	}
+	return f.value
+} //FlagDescription.get()
+
+//typeName describes the type of the flag value in usage, e.g. "integer"
+func (f FlagDescription) typeName() string {
+	if t, ok := f.value.(interface{ TypeName() string }); ok {
+		return t.TypeName()
+	}
+	return "value"
+} //FlagDescription.typeName()
+
+//set parses and validates a value for the flag
+func (f *FlagDescription) set(s string) error {
+	if err := f.value.Set(s); err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return f.invalid(s, err, fmt.Sprintf("%n value \"%s\" is out of range for <%s>", f, s, f.typeName()))
+		}
+		if !errors.Is(err, strconv.ErrSyntax) {
+			return f.invalid(s, err, fmt.Sprintf("%n value \"%s\" is not valid: %v", f, s, err))
+		}
+		return f.invalid(s, err, fmt.Sprintf("Expecting %s", f.expecting()))
+	}
+	return f.validateValue(s)
+} //FlagDescription.set()
+
+//expecting describes how the flag value is specified,
+//e.g. "-l <integer> or --limit=<integer>"
+func (f FlagDescription) expecting() string {
+	if f.name != "" {
+		return fmt.Sprintf("%n to be <%s>", f, f.typeName())
+	}
+	expect := make([]string, 0, 2)
+	if f.short != "" {
+		expect = append(expect, fmt.Sprintf("%s <%s>", f.short, f.typeName()))
+	}
+	if f.long != "" {
+		expect = append(expect, fmt.Sprintf("%s=<%s>", f.long, f.typeName()))
+	}
+	return strings.Join(expect, " or ")
+} //FlagDescription.expecting()
+
+//invalid makes an InvalidValueError for value s, to which the parser
+//adds the command line argument, with suggestions for mistyped names
+func (f *FlagDescription) invalid(s string, err error, message string) error {
+	return &InvalidValueError{Flag: f, Value: s, Index: -1, Err: err, Suggestions: suggest(s, f.allowed()), message: message}
+} //FlagDescription.invalid()
+
+//validateValue validates the value set from text s, or each element added to a list
+func (f *FlagDescription) validateValue(s string) error {
+	if f.validate != nil || len(f.validators) > 0 {
+		values := []interface{}{f.get()}
+		if l, ok := f.value.(interface{ added() []interface{} }); ok {
+			values = l.added()
+		}
+		for _, value := range values {
+			if f.validate != nil {
+				if err := f.validate(value); err != nil {
+					return f.invalid(s, err, fmt.Sprintf("%n value \"%s\" is not valid: %v", f, s, err))
+				}
+			}
+			for _, v := range f.validators {
+				if err := v.Validate(value); err != nil {
+					return f.invalid(s, err, fmt.Sprintf("%n value \"%s\" is not valid: %v", f, s, err))
+				}
+			}
+		}
+	}
+	return nil
+} //FlagDescription.validateValue()
+
+//setFrom sets a value from the specified source, where the first value in a
+//list from a source replaces values from sources of lower precedence
+func (f *FlagDescription) setFrom(src source, s string) error {
+	if f.source < src {
+		if l, ok := f.value.(interface{ reset() }); ok {
+			l.reset()
+		}
+	}
+	if err := f.set(s); err != nil {
+		return err
+	}
+	f.source = src
+	return nil
+} //FlagDescription.setFrom()
+
+//resetDefault restores the default value of the flag, e.g. to discard a value
+//from the environment that a mutually exclusive flag on the command line overrules
+//Values that cannot restore a saved copy are set to the text of their default.
+func (f *FlagDescription) resetDefault() error {
+	if r, ok := f.value.(restorer); ok {
+		r.restore(f.savedDefault)
+	} else if err := f.value.Set(f.defaultValue); err != nil {
+		return fmt.Errorf("Cannot restore the default of %n: %w", f, err)
+	}
+	f.source = sourceDefault
+	return nil
+} //FlagDescription.resetDefault()
@@ -0,0 +1,92 @@
+package flags
+
+import (
+	"errors"
+	"testing"
+)
+
+//toggle is a user-defined Value specified without a value, with a type name and a Getter
+type toggle struct {
+	on bool
+}
+
+func (v *toggle) Set(s string) error {
+	switch s {
+	case "true", "on":
+		v.on = true
+	case "false", "off":
+		v.on = false
+	default:
+		return errors.New("expecting on or off")
+	}
+	return nil
+}
+
+func (v *toggle) String() string {
+	if v.on {
+		return "on"
+	}
+	return "off"
+}
+
+func (v *toggle) Get() interface{} { return v.on }
+func (v *toggle) IsBool() bool     { return true }
+func (v *toggle) TypeName() string { return "switch" }
+
+func TestSetVar(t *testing.T) {
+	lvl := level(1)
+	sw := &toggle{}
+	set := NewSet("test", "Test set")
+	levelFlag, err := set.Var("-l", "--level", &lvl, "Level")
+	if err != nil {
+		t.Fatal(err)
+	}
+	toggleFlag, err := set.Var("-s", "--switch", sw, "Switch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := set.Var("-n", "", nil, "Nothing"); err == nil {
+		t.Errorf("expected Var() without a value to fail")
+	}
+	if levelFlag.defaultValue != "high" || levelFlag.typeName() != "value" || toggleFlag.typeName() != "switch" {
+		t.Errorf("got default %s and types %s and %s", levelFlag.defaultValue, levelFlag.typeName(), toggleFlag.typeName())
+	}
+
+	if err := set.Parse([]string{"--level=low", "-s"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if lvl != 0 || !sw.on {
+		t.Errorf("got level %v and switch %v", lvl, sw.on)
+	}
+	//without a Getter, the value is the Value itself
+	if levelFlag.Value() != &lvl || toggleFlag.Value() != true {
+		t.Errorf("got values %v and %v", levelFlag.Value(), toggleFlag.Value())
+	}
+
+	var invalid *InvalidValueError
+	err = set.Parse([]string{"-l", "medium"})
+	if !errors.As(err, &invalid) || err.Error() != `-l value "medium" is not valid: unknown level medium` {
+		t.Errorf("expected invalid level, got %v", err)
+	}
+	if err := set.Parse([]string{"--switch=false"}); err != nil || sw.on {
+		t.Errorf("expected --switch=false to turn it off, got %v", err)
+	}
+} //TestSetVar()
+
+func TestSetVarCopy(t *testing.T) {
+	lvl := level(0)
+	other := NewSet("other", "Other set")
+	if _, err := other.Var("-l", "--level", &lvl, "Level"); err != nil {
+		t.Fatal(err)
+	}
+	set := NewSet("test", "Test set")
+	if err := set.AddSet(*other); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Parse([]string{"-l", "high"}); err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if lvl != 1 {
+		t.Errorf("parsing the copied flag did not set the shared value")
+	}
+} //TestSetVarCopy()